@@ -2,6 +2,7 @@ package assert_test
 
 import (
 	"errors"
+	"fmt"
 	"math"
 	"os"
 	"testing"
@@ -12,6 +13,7 @@ import (
 type recorder struct {
 	testing.TB
 	fatalCalled bool
+	message     string
 }
 
 func newRecorder(t *testing.T) *recorder {
@@ -24,6 +26,9 @@ func (r *recorder) Fatal(args ...any) {
 
 func (r *recorder) Fatalf(format string, args ...any) {
 	r.Fatal()
+	if r.message == "" {
+		r.message = fmt.Sprintf(format, args...)
+	}
 }
 
 func (r *recorder) AssertFatalCalled() {
@@ -47,7 +52,7 @@ func TestEqualFail(t *testing.T) {
 func TestEqualSlices(t *testing.T) {
 	assert.EqualSlices(t, []int{1, 2}, []int{1, 2})
 	assert.EqualSlices(t, []string{"a", "b"}, []string{"a", "b"})
-	assert.EqualSlices[int](t, nil, nil)
+	assert.EqualSlices[int, []int](t, nil, nil)
 }
 
 func TestEqualSlicesFail(t *testing.T) {
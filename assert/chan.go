@@ -0,0 +1,44 @@
+package assert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zpatrick/testx/internal/assertion"
+)
+
+// Receives calls t.Fatalf if a value is not received from ch within timeout, or if ch is closed.
+// It returns the received value.
+func Receives[T any](t testing.TB, ch <-chan T, timeout time.Duration) T {
+	t.Helper()
+
+	v, _ := assertion.Receives(t, t.Fatalf, ch, timeout)
+	return v
+}
+
+// ReceivesValue calls t.Fatalf if a value equal to expected is not received from ch within timeout.
+func ReceivesValue[T comparable](t testing.TB, ch <-chan T, expected T, timeout time.Duration) {
+	t.Helper()
+	assertion.ReceivesValue(t, t.Fatalf, ch, expected, timeout)
+}
+
+// NotReceives calls t.Fatalf if a value is received from ch, or ch is closed, within d.
+// A value which is already waiting in ch is reported even if d is 0.
+func NotReceives[T any](t testing.TB, ch <-chan T, d time.Duration) {
+	t.Helper()
+	assertion.NotReceives(t, t.Fatalf, ch, d)
+}
+
+// Closed calls t.Fatalf if ch is not closed within timeout, or if a value is received from it instead.
+func Closed[T any](t testing.TB, ch <-chan T, timeout time.Duration) {
+	t.Helper()
+	assertion.Closed(t, t.Fatalf, ch, timeout)
+}
+
+// Drains calls t.Fatalf if the values received from ch before it is closed are not equal to expected,
+// or if ch is not closed within timeout.
+// The failure message shows the values which were received.
+func Drains[T comparable](t testing.TB, ch <-chan T, expected []T, timeout time.Duration) {
+	t.Helper()
+	assertion.Drains(t, t.Fatalf, ch, expected, timeout)
+}
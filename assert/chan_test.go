@@ -0,0 +1,93 @@
+package assert_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zpatrick/testx/assert"
+)
+
+// values returns a closed channel containing vs.
+func values[T any](vs ...T) chan T {
+	ch := make(chan T, len(vs))
+	for _, v := range vs {
+		ch <- v
+	}
+
+	close(ch)
+	return ch
+}
+
+func TestChannels(t *testing.T) {
+	assert.Equal(t, assert.Receives(t, values(1), time.Second), 1)
+	assert.ReceivesValue(t, values("a"), "a", time.Second)
+	assert.NotReceives(t, make(chan int), time.Millisecond)
+	assert.Closed(t, values[int](), time.Second)
+	assert.Drains(t, values(1, 2, 3), []int{1, 2, 3}, time.Second)
+
+	ch := make(chan int)
+	go func() {
+		defer close(ch)
+		for i := 0; i < 3; i++ {
+			ch <- i
+		}
+	}()
+
+	assert.Drains(t, ch, []int{0, 1, 2}, time.Second)
+}
+
+func TestChannels_readyBeforeTimeout(t *testing.T) {
+	// Values and closes which are already ready must win over an expired timeout.
+	// select chooses randomly between ready cases, so repeat to catch regressions.
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, assert.Receives(t, values(1), 0), 1)
+		assert.ReceivesValue(t, values("a"), "a", 0)
+		assert.Closed(t, values[int](), 0)
+		assert.Drains(t, values(1, 2, 3), []int{1, 2, 3}, time.Nanosecond)
+
+		r := newRecorder(t)
+		assert.NotReceives(r, values(1), 0)
+		r.AssertFatalCalled()
+	}
+}
+
+func TestChannelsFail(t *testing.T) {
+	blocked := make(chan int, 2)
+	blocked <- 1
+	blocked <- 2
+
+	testCases := []struct {
+		Name    string
+		Assert  func(t testing.TB)
+		Message string
+	}{
+		{"Receives timeout", func(t testing.TB) { assert.Receives(t, make(chan int), time.Millisecond) }, "no value received within 1ms"},
+		{"Receives closed", func(t testing.TB) { assert.Receives(t, values[int](), time.Second) }, "channel was closed before a value was received"},
+		{"ReceivesValue", func(t testing.TB) { assert.ReceivesValue(t, values(1), 2, time.Second) }, "received 1 != 2"},
+		{"ReceivesValue timeout", func(t testing.TB) {
+			assert.ReceivesValue(t, make(chan int), 2, time.Millisecond)
+		}, "no value received within 1ms (expected 2)"},
+		{"NotReceives", func(t testing.TB) { assert.NotReceives(t, values(1), time.Second) }, "received 1 within 1s"},
+		{"NotReceives zero duration", func(t testing.TB) { assert.NotReceives(t, values(1), 0) }, "received 1 within 0s"},
+		{"NotReceives closed", func(t testing.TB) { assert.NotReceives(t, values[int](), time.Second) }, "channel was closed within 1s"},
+		{"Closed", func(t testing.TB) { assert.Closed(t, values(1), time.Second) }, "received 1 instead of channel close"},
+		{"Closed timeout", func(t testing.TB) { assert.Closed(t, make(chan int), time.Millisecond) }, "channel was not closed within 1ms"},
+		{"Drains", func(t testing.TB) {
+			assert.Drains(t, values(1, 3), []int{1, 2}, time.Second)
+		}, "values received before channel close are not equal to expected:\nslices are not equal:\n  index 1: 3 != 2"},
+		{"Drains timeout", func(t testing.TB) {
+			assert.Drains(t, blocked, []int{1, 2, 3}, time.Millisecond)
+		}, "channel was not closed within 1ms; received 2 values: [1 2] (expected [1 2 3])"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			r := newRecorder(t)
+			tc.Assert(r)
+			r.AssertFatalCalled()
+
+			assert.HasPrefix(t, r.message, tc.Message)
+		})
+	}
+}
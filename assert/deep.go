@@ -0,0 +1,54 @@
+package assert
+
+import (
+	"testing"
+
+	"github.com/zpatrick/testx/internal/assertion"
+)
+
+// A DeepOption configures how DeepEqual compares values.
+type DeepOption = assertion.DeepOption
+
+// IgnoreFields skips struct fields with the given names.
+// A name can either be a bare field name (e.g. "UpdatedAt"), which matches the field in any struct type,
+// or qualified by its struct type's name (e.g. "User.UpdatedAt").
+func IgnoreFields(names ...string) DeepOption {
+	return assertion.IgnoreFields(names...)
+}
+
+// IgnoreUnexported skips unexported struct fields.
+func IgnoreUnexported() DeepOption {
+	return assertion.IgnoreUnexported()
+}
+
+// NilEqualsEmpty treats nil slices and maps as equal to empty ones.
+func NilEqualsEmpty() DeepOption {
+	return assertion.NilEqualsEmpty()
+}
+
+// SortSlices sorts slices (and arrays) of E using less before comparing them,
+// so the order of their elements is ignored.
+// It also applies to values reached through unexported fields.
+func SortSlices[E any](less func(a, b E) bool) DeepOption {
+	return assertion.SortSlices(less)
+}
+
+// Comparator compares values of type T using equal instead of walking them.
+// It also applies to values reached through unexported fields.
+func Comparator[T any](equal func(result, expected T) bool) DeepOption {
+	return assertion.Comparator(equal)
+}
+
+// MaxDiffs sets the maximum number of mismatches reported (10 by default).
+// Values less than 1 are treated as 1.
+func MaxDiffs(n int) DeepOption {
+	return assertion.MaxDiffs(n)
+}
+
+// DeepEqual calls t.Fatalf if result and expected are not deeply equal.
+// Unlike Equal, T does not need to be comparable: structs, slices, maps, and pointers are compared recursively.
+// The failure message lists the path (e.g. ".Users[0].Name") of each mismatch found, up to the limit set by MaxDiffs.
+func DeepEqual[T any](t testing.TB, result, expected T, opts ...DeepOption) {
+	t.Helper()
+	assertion.DeepEqual(t, t.Fatalf, result, expected, opts...)
+}
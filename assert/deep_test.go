@@ -0,0 +1,189 @@
+package assert_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zpatrick/testx/assert"
+)
+
+type deepUser struct {
+	Name      string
+	Tags      []string
+	Meta      map[string]int
+	Manager   *deepUser
+	UpdatedAt time.Time
+	secret    string
+}
+
+func TestDeepEqual(t *testing.T) {
+	now := time.Now()
+	assert.DeepEqual[error](t, nil, nil)
+	assert.DeepEqual(t, []int{1, 2}, []int{1, 2})
+	assert.DeepEqual(t,
+		deepUser{Name: "a", Tags: []string{"x"}, Meta: map[string]int{"k": 1}, Manager: &deepUser{Name: "b"}, UpdatedAt: now},
+		deepUser{Name: "a", Tags: []string{"x"}, Meta: map[string]int{"k": 1}, Manager: &deepUser{Name: "b"}, UpdatedAt: now},
+	)
+}
+
+func TestDeepEqual_cycle(t *testing.T) {
+	a, b := &deepUser{Name: "a"}, &deepUser{Name: "a"}
+	a.Manager, b.Manager = a, b
+
+	assert.DeepEqual(t, a, b)
+}
+
+func TestDeepEqual_options(t *testing.T) {
+	assert.DeepEqual(t,
+		deepUser{Name: "a", UpdatedAt: time.Now(), secret: "x"},
+		deepUser{Name: "a", secret: "y"},
+		assert.IgnoreFields("deepUser.UpdatedAt"),
+		assert.IgnoreUnexported(),
+	)
+
+	assert.DeepEqual(t, deepUser{Tags: []string{}}, deepUser{Meta: map[string]int{}}, assert.NilEqualsEmpty())
+
+	assert.DeepEqual(t,
+		[]string{"b", "c", "a"},
+		[]string{"a", "b", "c"},
+		assert.SortSlices(func(a, b string) bool { return a < b }),
+	)
+
+	assert.DeepEqual(t,
+		deepUser{Name: "ALICE"},
+		deepUser{Name: "alice"},
+		assert.Comparator(strings.EqualFold),
+	)
+}
+
+type deepUnexported struct {
+	inner deepInner
+}
+
+type deepInner struct {
+	m    map[string]int
+	tags []string
+	// any holds values which are not addressable when walked, e.g. a struct in an interface.
+	any any
+}
+
+func TestDeepEqual_optionsUnexported(t *testing.T) {
+	assert.DeepEqual(t,
+		deepUnexported{deepInner{m: map[string]int{"x": 1}, any: deepInner{tags: []string{"a"}}}},
+		deepUnexported{deepInner{m: map[string]int{"x": 2}, any: deepInner{tags: []string{"b"}}}},
+		assert.Comparator(func(a, b []string) bool { return true }),
+		assert.Comparator(func(a, b int) bool { return true }),
+	)
+
+	assert.DeepEqual(t,
+		deepUnexported{deepInner{tags: []string{"b", "a"}, any: deepInner{tags: []string{"d", "c"}}}},
+		deepUnexported{deepInner{tags: []string{"a", "b"}, any: deepInner{tags: []string{"c", "d"}}}},
+		assert.SortSlices(func(a, b string) bool { return a < b }),
+	)
+}
+
+func TestDeepEqualFail(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Result   deepUser
+		Expected deepUser
+		Options  []assert.DeepOption
+		Message  string
+	}{
+		{
+			Name:     "field",
+			Result:   deepUser{Name: "a"},
+			Expected: deepUser{Name: "b"},
+			Message:  `.Name: "a" != "b"`,
+		},
+		{
+			Name:     "nested slice element",
+			Result:   deepUser{Manager: &deepUser{Tags: []string{"x", "y"}}},
+			Expected: deepUser{Manager: &deepUser{Tags: []string{"x", "z"}}},
+			Message:  `.Manager.Tags[1]: "y" != "z"`,
+		},
+		{
+			Name:     "missing map key",
+			Result:   deepUser{Meta: map[string]int{}},
+			Expected: deepUser{Meta: map[string]int{"k": 1}},
+			Message:  ".Meta[k]: missing from result (expected 1)",
+		},
+		{
+			Name:     "unexpected map key",
+			Result:   deepUser{Meta: map[string]int{"k": 1}},
+			Expected: deepUser{Meta: map[string]int{}},
+			Message:  ".Meta[k]: unexpected in result (1)",
+		},
+		{
+			Name:     "nil and empty",
+			Result:   deepUser{Tags: []string{}},
+			Expected: deepUser{},
+			Message:  ".Tags: [] != nil",
+		},
+		{
+			Name:     "unexported",
+			Result:   deepUser{secret: "x"},
+			Expected: deepUser{secret: "y"},
+			Message:  `.secret: "x" != "y"`,
+		},
+		{
+			Name:     "comparator",
+			Result:   deepUser{Name: "a"},
+			Expected: deepUser{Name: "b"},
+			Options:  []assert.DeepOption{assert.Comparator(strings.EqualFold)},
+			Message:  ".Name: a != b (using comparator)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			r := newRecorder(t)
+			assert.DeepEqual(r, tc.Result, tc.Expected, tc.Options...)
+			r.AssertFatalCalled()
+
+			if !strings.Contains(r.message, tc.Message) {
+				t.Fatalf("message %q does not contain %q", r.message, tc.Message)
+			}
+		})
+	}
+}
+
+func TestDeepEqualFail_maxDiffs(t *testing.T) {
+	r := newRecorder(t)
+	assert.DeepEqual(r, []int{1, 2, 3}, []int{4, 5, 6}, assert.MaxDiffs(2))
+	r.AssertFatalCalled()
+
+	expected := strings.Join([]string{
+		"values are not deeply equal:",
+		"[0]: 1 != 4",
+		"[1]: 2 != 5",
+		"... (more differences omitted)",
+	}, "\n")
+
+	assert.Equal(t, r.message, expected)
+}
+
+func TestDeepEqualFail_maxDiffsReached(t *testing.T) {
+	r := newRecorder(t)
+	assert.DeepEqual(r, []int{1, 2}, []int{3, 2}, assert.MaxDiffs(1))
+	r.AssertFatalCalled()
+
+	assert.Equal(t, r.message, "values are not deeply equal:\n[0]: 1 != 3")
+}
+
+func TestDeepEqualFail_nonPositiveMaxDiffs(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		r := newRecorder(t)
+		assert.DeepEqual(r, []int{1, 2}, []int{3, 4}, assert.MaxDiffs(n))
+		r.AssertFatalCalled()
+
+		expected := strings.Join([]string{
+			"values are not deeply equal:",
+			"[0]: 1 != 3",
+			"... (more differences omitted)",
+		}, "\n")
+
+		assert.Equal(t, r.message, expected)
+	}
+}
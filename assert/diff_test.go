@@ -0,0 +1,100 @@
+package assert_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zpatrick/testx/assert"
+)
+
+func TestEqualSlicesFail_diff(t *testing.T) {
+	r := newRecorder(t)
+	assert.EqualSlices(r, []int{1, 2, 3, 4, 5, 6, 7, 8}, []int{1, 2, 3, 4, 5, 6, 8})
+	r.AssertFatalCalled()
+
+	expected := strings.Join([]string{
+		"--- expected",
+		"+++ result",
+		"  ...",
+		"  [4] 5",
+		"  [5] 6",
+		"+ [6] 7",
+		"  [6] 8",
+	}, "\n")
+
+	assert.Equal(t, strings.Contains(r.message, expected), true)
+}
+
+func TestEqualMapsFail_diff(t *testing.T) {
+	r := newRecorder(t)
+	assert.EqualMaps(r, map[string]int{"a": 1, "b": 3, "d": 4}, map[string]int{"a": 1, "b": 2, "c": 3})
+	r.AssertFatalCalled()
+
+	expected := strings.Join([]string{
+		"--- expected",
+		"+++ result",
+		"- [b] 2",
+		"+ [b] 3",
+		"- [c] 3",
+		"+ [d] 4",
+	}, "\n")
+
+	assert.Equal(t, strings.Contains(r.message, expected), true)
+}
+
+func TestEqualFail_multiLineDiff(t *testing.T) {
+	r := newRecorder(t)
+	assert.Equal(r, "alpha\nbravo\ncharlie", "alpha\nbeta\ncharlie")
+	r.AssertFatalCalled()
+
+	expected := strings.Join([]string{
+		"strings are not equal:",
+		"--- expected",
+		"+++ result",
+		"     1 | alpha",
+		"-    2 | beta",
+		"+    2 | bravo",
+		"     3 | charlie",
+	}, "\n")
+
+	assert.Equal(t, r.message, expected)
+}
+
+func TestEqualSlicesFail_lastElement(t *testing.T) {
+	r := newRecorder(t)
+	assert.EqualSlices(r, []int{1, 2, 3}, []int{1, 2, 4})
+	r.AssertFatalCalled()
+
+	assert.Equal(t, strings.Contains(r.message, "index 2: 3 != 4"), true)
+}
+
+func TestEqualSlicesFail_allMismatches(t *testing.T) {
+	r := newRecorder(t)
+	assert.EqualSlices(r, []int{0, 2, 0, 4, 5}, []int{1, 2, 3, 4})
+	r.AssertFatalCalled()
+
+	expected := strings.Join([]string{
+		"slices are not equal:",
+		"  length: 5 != 4",
+		"  index 0: 0 != 1",
+		"  index 2: 0 != 3",
+		"  index 4: unexpected element 5",
+	}, "\n")
+
+	assert.Equal(t, strings.HasPrefix(r.message, expected+"\n--- expected"), true)
+}
+
+func TestEqualMapsFail_allMismatches(t *testing.T) {
+	r := newRecorder(t)
+	assert.EqualMaps(r, map[string]int{"a": 1, "b": 3, "d": 4}, map[string]int{"a": 1, "b": 2, "c": 3})
+	r.AssertFatalCalled()
+
+	expected := strings.Join([]string{
+		"maps are not equal:",
+		"  key b: 3 != 2",
+		"  key c: missing (expected 3)",
+		"  key d: unexpected 4",
+	}, "\n")
+
+	assert.Equal(t, strings.HasPrefix(r.message, expected+"\n--- expected"), true)
+}
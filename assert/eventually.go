@@ -0,0 +1,48 @@
+package assert
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zpatrick/testx/internal/assertion"
+)
+
+// Eventually calls t.Fatalf if cond does not return true within timeout.
+// cond is called immediately, then once every interval.
+// A non-positive timeout or interval is reported as a failure.
+func Eventually(t testing.TB, cond func() bool, timeout, interval time.Duration) {
+	t.Helper()
+	assertion.Eventually(t, t.Fatalf, cond, timeout, interval)
+}
+
+// Consistently calls t.Fatalf if cond returns false at any point during duration.
+// cond is called immediately, then once every interval.
+// A non-positive duration or interval is reported as a failure.
+func Consistently(t testing.TB, cond func() bool, duration, interval time.Duration) {
+	t.Helper()
+	assertion.Consistently(t, t.Fatalf, cond, duration, interval)
+}
+
+// Never calls t.Fatalf if cond returns true at any point during duration.
+// cond is called immediately, then once every interval.
+// A non-positive duration or interval is reported as a failure.
+func Never(t testing.TB, cond func() bool, duration, interval time.Duration) {
+	t.Helper()
+	assertion.Never(t, t.Fatalf, cond, duration, interval)
+}
+
+// EventuallyWithT calls t.Fatalf if cond does not complete without failing within timeout.
+// A non-positive timeout or interval is reported as a failure.
+// cond is called immediately, then once every interval, with a testing.TB which collects failures
+// instead of reporting them to t, so assertions made against it are retried, e.g.
+//
+//	assert.EventuallyWithT(t, func(c testing.TB) {
+//		assert.NilError(c, db.Ping())
+//	}, time.Minute, time.Second)
+//
+// Calling Fatal (or FailNow) on c ends the current attempt.
+// If timeout is reached, the failure message includes the failures of the last attempt.
+func EventuallyWithT(t testing.TB, cond func(c testing.TB), timeout, interval time.Duration) {
+	t.Helper()
+	assertion.EventuallyWithT(t, t.Fatalf, cond, timeout, interval)
+}
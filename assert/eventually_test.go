@@ -0,0 +1,97 @@
+package assert_test
+
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zpatrick/testx/assert"
+)
+
+// counter returns a condition which returns true once it has been called n times.
+func counter(n int32) func() bool {
+	var calls int32
+	return func() bool {
+		return atomic.AddInt32(&calls, 1) >= n
+	}
+}
+
+func TestEventually(t *testing.T) {
+	assert.Eventually(t, counter(3), time.Second, time.Millisecond)
+	assert.Consistently(t, func() bool { return true }, 10*time.Millisecond, time.Millisecond)
+	assert.Never(t, func() bool { return false }, 10*time.Millisecond, time.Millisecond)
+}
+
+func TestEventuallyFail(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Assert  func(t testing.TB)
+		Message string
+	}{
+		{"Eventually", func(t testing.TB) {
+			assert.Eventually(t, func() bool { return false }, 10*time.Millisecond, time.Millisecond)
+		}, "condition was not met within 10ms"},
+		{"Consistently", func(t testing.TB) {
+			cond := counter(3)
+			assert.Consistently(t, func() bool { return !cond() }, time.Second, time.Millisecond)
+		}, "condition was not met on check 3"},
+		{"Eventually zero interval", func(t testing.TB) {
+			assert.Eventually(t, func() bool { return true }, 10*time.Millisecond, 0)
+		}, "invalid interval 0s: must be positive"},
+		{"Consistently negative duration", func(t testing.TB) {
+			assert.Consistently(t, func() bool { return true }, -time.Second, time.Millisecond)
+		}, "invalid duration -1s: must be positive"},
+		{"EventuallyWithT zero timeout", func(t testing.TB) {
+			assert.EventuallyWithT(t, func(c testing.TB) {}, 0, time.Millisecond)
+		}, "invalid timeout 0s: must be positive"},
+		{"Never", func(t testing.TB) {
+			assert.Never(t, counter(2), time.Second, time.Millisecond)
+		}, "condition was met on check 2"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			r := newRecorder(t)
+			tc.Assert(r)
+			r.AssertFatalCalled()
+
+			assert.HasPrefix(t, r.message, tc.Message)
+		})
+	}
+}
+
+func TestEventuallyWithT(t *testing.T) {
+	var calls, cleanups int
+	assert.EventuallyWithT(t, func(c testing.TB) {
+		calls++
+		c.Cleanup(func() { cleanups++ })
+		assert.Equal(c, calls, 3)
+	}, time.Second, time.Millisecond)
+
+	assert.Equal(t, calls, 3)
+	assert.Equal(t, cleanups, 3)
+}
+
+func TestEventuallyWithTFail(t *testing.T) {
+	var calls int
+	r := newRecorder(t)
+	assert.EventuallyWithT(r, func(c testing.TB) {
+		calls++
+		c.Errorf("attempt %d failed", calls)
+		assert.Equal(c, calls, 0)
+		c.Errorf("unreachable")
+	}, 10*time.Millisecond, time.Millisecond)
+	r.AssertFatalCalled()
+
+	expected := strings.Join([]string{
+		"; last attempt failed with:",
+		"  attempt " + strconv.Itoa(calls) + " failed",
+		"  " + strconv.Itoa(calls) + " != 0",
+	}, "\n")
+
+	assert.HasPrefix(t, r.message, "condition was not met within 10ms")
+	assert.HasSuffix(t, r.message, expected)
+}
@@ -0,0 +1,66 @@
+package assert
+
+import (
+	"testing"
+
+	"github.com/zpatrick/testx/internal/assertion"
+)
+
+// Float is a constraint that permits any floating-point type.
+type Float = assertion.Float
+
+// InDelta calls t.Fatalf if the absolute difference between result and expected is greater than delta.
+// NaN is considered equal to NaN, and infinities are equal to infinities of the same sign.
+func InDelta[T Float](t testing.TB, result, expected, delta T) {
+	t.Helper()
+	assertion.InDelta(t, t.Fatalf, result, expected, delta)
+}
+
+// InEpsilon calls t.Fatalf if the relative error |result-expected|/|expected| is greater than epsilon.
+// NaN is considered equal to NaN, and infinities are equal to infinities of the same sign.
+// If expected is 0, result must also be 0.
+func InEpsilon[T Float](t testing.TB, result, expected, epsilon T) {
+	t.Helper()
+	assertion.InEpsilon(t, t.Fatalf, result, expected, epsilon)
+}
+
+// InDeltaSlices calls t.Fatalf if result and expected are not the same length
+// or any pair of elements fails InDelta.
+func InDeltaSlices[T Float, TS ~[]T](t testing.TB, result, expected TS, delta T) {
+	t.Helper()
+	assertion.InDeltaSlices(t, t.Fatalf, []T(result), []T(expected), delta)
+}
+
+// InEpsilonSlices calls t.Fatalf if result and expected are not the same length
+// or any pair of elements fails InEpsilon.
+func InEpsilonSlices[T Float, TS ~[]T](t testing.TB, result, expected TS, epsilon T) {
+	t.Helper()
+	assertion.InEpsilonSlices(t, t.Fatalf, []T(result), []T(expected), epsilon)
+}
+
+// InDeltaMaps calls t.Fatalf if result and expected do not have the same keys
+// or the values of any key fail InDelta.
+func InDeltaMaps[K comparable, T Float](t testing.TB, result, expected map[K]T, delta T) {
+	t.Helper()
+	assertion.InDeltaMaps(t, t.Fatalf, result, expected, delta)
+}
+
+// InEpsilonMaps calls t.Fatalf if result and expected do not have the same keys
+// or the values of any key fail InEpsilon.
+func InEpsilonMaps[K comparable, T Float](t testing.TB, result, expected map[K]T, epsilon T) {
+	t.Helper()
+	assertion.InEpsilonMaps(t, t.Fatalf, result, expected, epsilon)
+}
+
+// IsNaN calls t.Fatalf if v is not NaN.
+func IsNaN[T Float](t testing.TB, v T) {
+	t.Helper()
+	assertion.IsNaN(t, t.Fatalf, v)
+}
+
+// IsInf calls t.Fatalf if v is not an infinity with the given sign.
+// As with math.IsInf, a sign > 0 requires +Inf, a sign < 0 requires -Inf, and 0 allows either.
+func IsInf[T Float](t testing.TB, v T, sign int) {
+	t.Helper()
+	assertion.IsInf(t, t.Fatalf, v, sign)
+}
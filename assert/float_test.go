@@ -0,0 +1,130 @@
+package assert_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/zpatrick/testx/assert"
+)
+
+func TestInDelta(t *testing.T) {
+	assert.InDelta(t, 0.1+0.2, 0.3, 1e-9)
+	assert.InDelta(t, float32(1.5), 1.0, 0.5)
+	assert.InDelta(t, math.NaN(), math.NaN(), 0)
+	assert.InDelta(t, math.Inf(1), math.Inf(1), 0)
+}
+
+func TestInDeltaFail(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Result   float64
+		Expected float64
+		Delta    float64
+		Message  string
+	}{
+		{"outside delta", 1, 1.5, 0.1, "1 and 1.5 differ by 0.5, more than delta 0.1"},
+		{"NaN", math.NaN(), 1, 0.1, "NaN != 1"},
+		{"opposite infinities", math.Inf(1), math.Inf(-1), math.Inf(1), "+Inf != -Inf"},
+		{"negative delta", 1, 1, -1, "invalid delta -1"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			r := newRecorder(t)
+			assert.InDelta(r, tc.Result, tc.Expected, tc.Delta)
+			r.AssertFatalCalled()
+
+			assert.Equal(t, strings.Contains(r.message, tc.Message), true)
+		})
+	}
+}
+
+func TestInEpsilon(t *testing.T) {
+	assert.InEpsilon(t, 101.0, 100, 0.01)
+	assert.InEpsilon(t, 0.0, 0, 0)
+	assert.InEpsilon(t, math.Inf(-1), math.Inf(-1), 0)
+}
+
+func TestInEpsilonFail(t *testing.T) {
+	testCases := []struct {
+		Name     string
+		Result   float64
+		Expected float64
+		Epsilon  float64
+		Message  string
+	}{
+		{"outside epsilon", 102, 100, 0.01, "102 and 100 have a relative error of 0.02, more than epsilon 0.01"},
+		{"expected zero", 0.001, 0, 0.5, "relative error of 0.001 is undefined when expected is 0"},
+		{"NaN epsilon", 1, 1, math.NaN(), "invalid epsilon NaN"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			r := newRecorder(t)
+			assert.InEpsilon(r, tc.Result, tc.Expected, tc.Epsilon)
+			r.AssertFatalCalled()
+
+			assert.Equal(t, strings.Contains(r.message, tc.Message), true)
+		})
+	}
+}
+
+func TestInDeltaSlices(t *testing.T) {
+	assert.InDeltaSlices(t, []float64{0.1 + 0.2, 1}, []float64{0.3, 1}, 1e-9)
+	assert.InEpsilonSlices(t, []float32{101, 200}, []float32{100, 200}, 0.02)
+}
+
+func TestInDeltaSlicesFail(t *testing.T) {
+	r := newRecorder(t)
+	assert.InDeltaSlices(r, []float64{1, 2, 3}, []float64{1.5, 2}, 0.1)
+	r.AssertFatalCalled()
+
+	expected := strings.Join([]string{
+		"slices are not equal within tolerance:",
+		"  length: 3 != 2",
+		"  index 0: 1 and 1.5 differ by 0.5, more than delta 0.1",
+	}, "\n")
+
+	assert.Equal(t, r.message, expected)
+}
+
+func TestInDeltaMaps(t *testing.T) {
+	assert.InDeltaMaps(t, map[string]float64{"a": 0.1 + 0.2}, map[string]float64{"a": 0.3}, 1e-9)
+	assert.InEpsilonMaps(t, map[string]float64{"a": 101}, map[string]float64{"a": 100}, 0.01)
+}
+
+func TestInDeltaMapsFail(t *testing.T) {
+	r := newRecorder(t)
+	assert.InDeltaMaps(r, map[string]float64{"a": 1, "c": 3}, map[string]float64{"a": 1.5, "b": 2}, 0.1)
+	r.AssertFatalCalled()
+
+	expected := strings.Join([]string{
+		"maps are not equal within tolerance:",
+		"  key a: 1 and 1.5 differ by 0.5, more than delta 0.1",
+		"  key b: missing (expected 2)",
+		"  key c: unexpected 3",
+	}, "\n")
+
+	assert.Equal(t, r.message, expected)
+}
+
+func TestIsNaN(t *testing.T) {
+	assert.IsNaN(t, math.NaN())
+
+	r := newRecorder(t)
+	assert.IsNaN(r, 1.0)
+	r.AssertFatalCalled()
+}
+
+func TestIsInf(t *testing.T) {
+	assert.IsInf(t, math.Inf(1), 1)
+	assert.IsInf(t, math.Inf(-1), 0)
+
+	r := newRecorder(t)
+	assert.IsInf(r, math.Inf(1), -1)
+	r.AssertFatalCalled()
+	assert.Equal(t, r.message, "+Inf is not -Inf")
+}
@@ -0,0 +1,55 @@
+package assert
+
+import (
+	"testing"
+
+	"github.com/zpatrick/testx/internal/assertion"
+)
+
+// Ordered is a constraint that permits any ordered type: any type that supports the operators < <= >= >.
+// It mirrors golang.org/x/exp/constraints.Ordered.
+type Ordered = assertion.Ordered
+
+// Greater calls t.Fatalf unless result > threshold.
+func Greater[T Ordered](t testing.TB, result, threshold T) {
+	t.Helper()
+	assertion.Greater(t, t.Fatalf, result, threshold)
+}
+
+// GreaterOrEqual calls t.Fatalf unless result >= threshold.
+func GreaterOrEqual[T Ordered](t testing.TB, result, threshold T) {
+	t.Helper()
+	assertion.GreaterOrEqual(t, t.Fatalf, result, threshold)
+}
+
+// Less calls t.Fatalf unless result < threshold.
+func Less[T Ordered](t testing.TB, result, threshold T) {
+	t.Helper()
+	assertion.Less(t, t.Fatalf, result, threshold)
+}
+
+// LessOrEqual calls t.Fatalf unless result <= threshold.
+func LessOrEqual[T Ordered](t testing.TB, result, threshold T) {
+	t.Helper()
+	assertion.LessOrEqual(t, t.Fatalf, result, threshold)
+}
+
+// Between calls t.Fatalf unless min <= result <= max.
+func Between[T Ordered](t testing.TB, result, min, max T) {
+	t.Helper()
+	assertion.Between(t, t.Fatalf, result, min, max)
+}
+
+// Sorted calls t.Fatalf unless s is sorted in ascending order.
+// The failure message shows the first pair of elements which are out of order.
+func Sorted[T Ordered, TS ~[]T](t testing.TB, s TS) {
+	t.Helper()
+	assertion.Sorted(t, t.Fatalf, []T(s))
+}
+
+// SortedFunc calls t.Fatalf unless s is sorted according to less.
+// The failure message shows the first pair of elements which are out of order.
+func SortedFunc[T any, TS ~[]T](t testing.TB, s TS, less func(a, b T) bool) {
+	t.Helper()
+	assertion.SortedFunc(t, t.Fatalf, []T(s), less)
+}
@@ -0,0 +1,48 @@
+package assert_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zpatrick/testx/assert"
+)
+
+func TestOrdered(t *testing.T) {
+	assert.Greater(t, 2, 1)
+	assert.GreaterOrEqual(t, 2, 2)
+	assert.Less(t, "a", "b")
+	assert.LessOrEqual(t, 1.5, 1.5)
+	assert.Between(t, time.Second, time.Second, time.Minute)
+	assert.Sorted(t, []int{1, 2, 2, 3})
+	assert.Sorted[int, []int](t, nil)
+	assert.SortedFunc(t, []string{"ccc", "bb", "a"}, func(a, b string) bool { return len(a) > len(b) })
+}
+
+func TestOrderedFail(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Assert  func(t testing.TB)
+		Message string
+	}{
+		{"Greater", func(t testing.TB) { assert.Greater(t, 1, 1) }, "1 is not greater than 1"},
+		{"GreaterOrEqual", func(t testing.TB) { assert.GreaterOrEqual(t, 1, 2) }, "1 is not greater than or equal to 2"},
+		{"Less", func(t testing.TB) { assert.Less(t, "b", "a") }, "b is not less than a"},
+		{"LessOrEqual", func(t testing.TB) { assert.LessOrEqual(t, 2.5, 2) }, "2.5 is not less than or equal to 2"},
+		{"Between", func(t testing.TB) { assert.Between(t, 11, 1, 10) }, "11 is not between 1 and 10 (inclusive)"},
+		{"Sorted", func(t testing.TB) { assert.Sorted(t, []int{1, 3, 2}) }, "slice is not sorted: element 2 (2) is less than element 1 (3)"},
+		{"SortedFunc", func(t testing.TB) {
+			assert.SortedFunc(t, []string{"a", "bb"}, func(a, b string) bool { return len(a) > len(b) })
+		}, "slice is not sorted: element 1 (bb) is less than element 0 (a)"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			r := newRecorder(t)
+			tc.Assert(r)
+			r.AssertFatalCalled()
+
+			assert.Equal(t, r.message, tc.Message)
+		})
+	}
+}
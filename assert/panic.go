@@ -0,0 +1,41 @@
+package assert
+
+import (
+	"testing"
+
+	"github.com/zpatrick/testx/internal/assertion"
+)
+
+// Panics calls t.Fatalf if fn does not panic.
+func Panics(t testing.TB, fn func()) {
+	t.Helper()
+	assertion.Panics(t, t.Fatalf, fn)
+}
+
+// NotPanics calls t.Fatalf if fn panics.
+// The failure message includes the recovered value and the stack trace of the panic.
+func NotPanics(t testing.TB, fn func()) {
+	t.Helper()
+	assertion.NotPanics(t, t.Fatalf, fn)
+}
+
+// PanicsWithValue calls t.Fatalf if fn does not panic with a value equal to expected.
+// The failure message includes the recovered value and the stack trace of the panic.
+func PanicsWithValue[T comparable](t testing.TB, fn func(), expected T) {
+	t.Helper()
+	assertion.PanicsWithValue(t, t.Fatalf, fn, expected)
+}
+
+// PanicsWithError calls t.Fatalf if fn does not panic with an error for which errors.Is(err, target) succeeds.
+// The failure message includes the recovered value and the stack trace of the panic.
+func PanicsWithError(t testing.TB, fn func(), target error) {
+	t.Helper()
+	assertion.PanicsWithError(t, t.Fatalf, fn, target)
+}
+
+// PanicsWithErrorAs calls t.Fatalf if fn does not panic with an error for which errors.As(err, target) succeeds.
+// The failure message includes the recovered value and the stack trace of the panic.
+func PanicsWithErrorAs(t testing.TB, fn func(), target any) {
+	t.Helper()
+	assertion.PanicsWithErrorAs(t, t.Fatalf, fn, target)
+}
@@ -0,0 +1,65 @@
+package assert_test
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/zpatrick/testx/assert"
+)
+
+func TestPanics(t *testing.T) {
+	assert.Panics(t, func() { panic("boom") })
+	assert.Panics(t, func() { panic(nil) })
+	assert.NotPanics(t, func() {})
+	assert.PanicsWithValue(t, func() { panic("boom") }, "boom")
+	assert.PanicsWithError(t, func() { panic(fmt.Errorf("wrapped: %w", os.ErrNotExist)) }, os.ErrNotExist)
+
+	var pathErr *os.PathError
+	assert.PanicsWithErrorAs(t, func() { panic(&os.PathError{Op: "open"}) }, &pathErr)
+	assert.Equal(t, pathErr.Op, "open")
+}
+
+func TestPanicsFail(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Assert  func(t testing.TB)
+		Message string
+		Stack   bool
+	}{
+		{"Panics", func(t testing.TB) { assert.Panics(t, func() {}) }, "function did not panic", false},
+		{"NotPanics", func(t testing.TB) { assert.NotPanics(t, func() { panic("boom") }) }, "function panicked: boom", true},
+		{"PanicsWithValue", func(t testing.TB) {
+			assert.PanicsWithValue(t, func() { panic("boom") }, "bang")
+		}, "panic value boom != bang", true},
+		{"PanicsWithValue type", func(t testing.TB) {
+			assert.PanicsWithValue(t, func() { panic(1) }, "bang")
+		}, "panic value 1 (int) is not of type string", true},
+		{"PanicsWithValue no panic", func(t testing.TB) {
+			assert.PanicsWithValue(t, func() {}, "bang")
+		}, "function did not panic (expected panic value bang)", false},
+		{"PanicsWithError", func(t testing.TB) {
+			assert.PanicsWithError(t, func() { panic(os.ErrExist) }, os.ErrNotExist)
+		}, "error.Is check failed for panic value file already exists (target: file does not exist)", true},
+		{"PanicsWithError not an error", func(t testing.TB) {
+			assert.PanicsWithError(t, func() { panic("boom") }, os.ErrNotExist)
+		}, "panic value boom (string) is not an error", true},
+		{"PanicsWithErrorAs", func(t testing.TB) {
+			var pathErr *os.PathError
+			assert.PanicsWithErrorAs(t, func() { panic(os.ErrExist) }, &pathErr)
+		}, "error.As check failed for panic value file already exists", true},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			r := newRecorder(t)
+			tc.Assert(r)
+			r.AssertFatalCalled()
+
+			assert.HasPrefix(t, r.message, tc.Message)
+			assert.Equal(t, strings.Contains(r.message, "goroutine "), tc.Stack)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package assert
+
+import (
+	"testing"
+
+	"github.com/zpatrick/testx/internal/assertion"
+)
+
+// StringContains calls t.Fatalf if s does not contain substr.
+func StringContains(t testing.TB, s, substr string) {
+	t.Helper()
+	assertion.StringContains(t, t.Fatalf, s, substr)
+}
+
+// HasPrefix calls t.Fatalf if s does not begin with prefix.
+func HasPrefix(t testing.TB, s, prefix string) {
+	t.Helper()
+	assertion.HasPrefix(t, t.Fatalf, s, prefix)
+}
+
+// HasSuffix calls t.Fatalf if s does not end with suffix.
+func HasSuffix(t testing.TB, s, suffix string) {
+	t.Helper()
+	assertion.HasSuffix(t, t.Fatalf, s, suffix)
+}
+
+// MatchesRegexp calls t.Fatalf if s does not match the regular expression pattern,
+// or if pattern cannot be compiled.
+func MatchesRegexp(t testing.TB, s, pattern string) {
+	t.Helper()
+	assertion.MatchesRegexp(t, t.Fatalf, s, pattern)
+}
+
+// EqualFold calls t.Fatalf if result and expected are not equal under Unicode case-folding.
+func EqualFold(t testing.TB, result, expected string) {
+	t.Helper()
+	assertion.EqualFold(t, t.Fatalf, result, expected)
+}
+
+// EqualLines calls t.Fatalf if result != expected.
+// The failure message points to the first differing line and column, followed by a line-level diff.
+func EqualLines(t testing.TB, result, expected string) {
+	t.Helper()
+	assertion.EqualLines(t, t.Fatalf, result, expected)
+}
@@ -0,0 +1,72 @@
+package assert_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zpatrick/testx/assert"
+)
+
+func TestStrings(t *testing.T) {
+	assert.StringContains(t, "hello world", "o w")
+	assert.HasPrefix(t, "hello world", "hello")
+	assert.HasSuffix(t, "hello world", "world")
+	assert.MatchesRegexp(t, "order-123", `^order-\d+$`)
+	assert.EqualFold(t, "Hello", "hELLO")
+	assert.EqualLines(t, "a\nb", "a\nb")
+}
+
+func TestStringsFail(t *testing.T) {
+	testCases := []struct {
+		Name    string
+		Assert  func(t testing.TB)
+		Message string
+	}{
+		{"StringContains", func(t testing.TB) { assert.StringContains(t, "hello", "x") }, `"hello" does not contain "x"`},
+		{"HasPrefix", func(t testing.TB) { assert.HasPrefix(t, "hello", "lo") }, `"hello" does not have prefix "lo"`},
+		{"HasSuffix", func(t testing.TB) { assert.HasSuffix(t, "hello", "he") }, `"hello" does not have suffix "he"`},
+		{"MatchesRegexp", func(t testing.TB) { assert.MatchesRegexp(t, "order-x", `^order-\d+$`) }, `"order-x" does not match regexp "^order-\\d+$"`},
+		{"MatchesRegexp invalid", func(t testing.TB) { assert.MatchesRegexp(t, "a", "(") }, `invalid regexp "(": `},
+		{"EqualFold", func(t testing.TB) { assert.EqualFold(t, "Hello", "world") }, `"Hello" is not equal to "world" (ignoring case)`},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.Name, func(t *testing.T) {
+			r := newRecorder(t)
+			tc.Assert(r)
+			r.AssertFatalCalled()
+
+			assert.HasPrefix(t, r.message, tc.Message)
+		})
+	}
+}
+
+func TestEqualLinesFail(t *testing.T) {
+	r := newRecorder(t)
+	assert.EqualLines(r, "alpha\nbravo\ncharlie", "alpha\nbeta\ncharlie")
+	r.AssertFatalCalled()
+
+	expected := strings.Join([]string{
+		"strings differ at line 2, column 2:",
+		"  result:   bravo",
+		"  expected: beta",
+		"             ^",
+		"--- expected",
+		"+++ result",
+		"     1 | alpha",
+		"-    2 | beta",
+		"+    2 | bravo",
+		"     3 | charlie",
+	}, "\n")
+
+	assert.Equal(t, r.message, expected)
+}
+
+func TestEqualLinesFail_missingLine(t *testing.T) {
+	r := newRecorder(t)
+	assert.EqualLines(r, "alpha", "alpha\nbravo")
+	r.AssertFatalCalled()
+
+	assert.HasPrefix(t, r.message, "strings differ at line 2, column 1:\n  result:   <no line>\n  expected: bravo\n")
+}
@@ -0,0 +1,42 @@
+package check
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zpatrick/testx/internal/assertion"
+)
+
+// Receives calls t.Errorf if a value is not received from ch within timeout, or if ch is closed.
+// It returns the received value and whether the check passed.
+func Receives[T any](t testing.TB, ch <-chan T, timeout time.Duration) (T, bool) {
+	t.Helper()
+	return assertion.Receives(t, t.Errorf, ch, timeout)
+}
+
+// ReceivesValue calls t.Errorf if a value equal to expected is not received from ch within timeout.
+func ReceivesValue[T comparable](t testing.TB, ch <-chan T, expected T, timeout time.Duration) bool {
+	t.Helper()
+	return assertion.ReceivesValue(t, t.Errorf, ch, expected, timeout)
+}
+
+// NotReceives calls t.Errorf if a value is received from ch, or ch is closed, within d.
+// A value which is already waiting in ch is reported even if d is 0.
+func NotReceives[T any](t testing.TB, ch <-chan T, d time.Duration) bool {
+	t.Helper()
+	return assertion.NotReceives(t, t.Errorf, ch, d)
+}
+
+// Closed calls t.Errorf if ch is not closed within timeout, or if a value is received from it instead.
+func Closed[T any](t testing.TB, ch <-chan T, timeout time.Duration) bool {
+	t.Helper()
+	return assertion.Closed(t, t.Errorf, ch, timeout)
+}
+
+// Drains calls t.Errorf if the values received from ch before it is closed are not equal to expected,
+// or if ch is not closed within timeout.
+// The failure message shows the values which were received.
+func Drains[T comparable](t testing.TB, ch <-chan T, expected []T, timeout time.Duration) bool {
+	t.Helper()
+	return assertion.Drains(t, t.Errorf, ch, expected, timeout)
+}
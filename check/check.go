@@ -0,0 +1,85 @@
+// Package check provides the same assertions as package assert, but reports failures using t.Errorf
+// so a test keeps running after a failed check.
+// Each check returns true if it passed, allowing callers to branch on the result.
+package check
+
+import (
+	"testing"
+
+	"github.com/zpatrick/testx/internal/assertion"
+)
+
+// Equal calls t.Errorf if result != expected.
+// If result and expected are multi-line strings, the failure message shows a line-level diff.
+func Equal[T comparable](t testing.TB, result, expected T) bool {
+	t.Helper()
+	return assertion.Equal(t, t.Errorf, result, expected)
+}
+
+// EqualSlices calls t.Errorf if result expected do not contain the same elements in the same order.
+// The failure message lists every differing index, followed by an element-level diff of the slices.
+func EqualSlices[T comparable, TS ~[]T](t testing.TB, result, expected TS) bool {
+	t.Helper()
+	return assertion.EqualSlices(t, t.Errorf, []T(result), []T(expected))
+}
+
+// EqualMaps calls t.Errorf if result expected do not contain the same elements.
+// The failure message lists every missing, extra, and changed key, followed by a diff of the maps.
+func EqualMaps[K, V comparable](t testing.TB, result, expected map[K]V) bool {
+	t.Helper()
+	return assertion.EqualMaps(t, t.Errorf, result, expected)
+}
+
+// A Comparable can be compared to other instances of the same type.
+type Comparable[T any] interface {
+	// Equal should return true if t is equal to the receiver.
+	Equal(t T) bool
+}
+
+// EqualC calls t.Errorf if result != expected.
+func EqualC[T any](t testing.TB, result Comparable[T], expected T) bool {
+	t.Helper()
+	return assertion.EqualC[T](t, t.Errorf, result, expected)
+}
+
+// Contains calls t.Errorf if any value v is not present in s.
+func Contains[T comparable](t testing.TB, s []T, v ...T) bool {
+	t.Helper()
+	return assertion.Contains(t, t.Errorf, s, v...)
+}
+
+// ContainsKeys calls t.Errorf if any of the specified keys are not present in m.
+func ContainsKeys[T comparable, A any](t testing.TB, m map[T]A, keys ...T) bool {
+	t.Helper()
+	return assertion.ContainsKeys(t, t.Errorf, m, keys...)
+}
+
+// ContainsVals calls t.Errorf if any of the specified vals are not present in m.
+func ContainsVals[A, T comparable](t testing.TB, m map[A]T, vals ...T) bool {
+	t.Helper()
+	return assertion.ContainsVals(t, t.Errorf, m, vals...)
+}
+
+// Error calls t.Errorf if err is nil.
+func Error(t testing.TB, err error) bool {
+	t.Helper()
+	return assertion.Error(t, t.Errorf, err)
+}
+
+// NilError calls t.Errorf if err is not nil.
+func NilError(t testing.TB, err error) bool {
+	t.Helper()
+	return assertion.NilError(t, t.Errorf, err)
+}
+
+// ErrorIs calls t.Errorf if errors.Is(err, target) fails.
+func ErrorIs(t testing.TB, err, target error) bool {
+	t.Helper()
+	return assertion.ErrorIs(t, t.Errorf, err, target)
+}
+
+// ErrorAs calls t.Errorf if errors.As(err, target) fails.
+func ErrorAs(t testing.TB, err error, target any) bool {
+	t.Helper()
+	return assertion.ErrorAs(t, t.Errorf, err, target)
+}
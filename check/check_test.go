@@ -0,0 +1,147 @@
+package check_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/zpatrick/testx/check"
+)
+
+type recorder struct {
+	testing.TB
+	errors      []string
+	fatalCalled bool
+}
+
+func newRecorder(t *testing.T) *recorder {
+	return &recorder{TB: t}
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...any) {
+	r.fatalCalled = true
+}
+
+type equalInt int
+
+func (e equalInt) Equal(other equalInt) bool {
+	return e == other
+}
+
+func TestChecks(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		Check func(t testing.TB) bool
+		Pass  bool
+	}{
+		{"Equal", func(t testing.TB) bool { return check.Equal(t, 1, 1) }, true},
+		{"Equal fail", func(t testing.TB) bool { return check.Equal(t, 1, 2) }, false},
+		{"EqualSlices", func(t testing.TB) bool { return check.EqualSlices(t, []int{1, 2}, []int{1, 2}) }, true},
+		{"EqualSlices fail", func(t testing.TB) bool { return check.EqualSlices(t, []int{1}, []int{1, 2}) }, false},
+		{"EqualMaps", func(t testing.TB) bool { return check.EqualMaps(t, map[int]int{1: 2}, map[int]int{1: 2}) }, true},
+		{"EqualMaps fail", func(t testing.TB) bool { return check.EqualMaps(t, map[int]int{1: 2}, map[int]int{1: 3}) }, false},
+		{"EqualC", func(t testing.TB) bool { return check.EqualC[equalInt](t, equalInt(1), 1) }, true},
+		{"EqualC fail", func(t testing.TB) bool { return check.EqualC[equalInt](t, equalInt(1), 2) }, false},
+		{"DeepEqual", func(t testing.TB) bool { return check.DeepEqual(t, []int{1}, []int{1}) }, true},
+		{"DeepEqual fail", func(t testing.TB) bool { return check.DeepEqual(t, []int{1}, []int{2}) }, false},
+		{"DeepEqual fail MaxDiffs(0)", func(t testing.TB) bool {
+			return check.DeepEqual(t, []int{1, 2}, []int{3, 4}, check.MaxDiffs(0))
+		}, false},
+		{"Contains", func(t testing.TB) bool { return check.Contains(t, []int{1, 2}, 2) }, true},
+		{"Contains fail", func(t testing.TB) bool { return check.Contains(t, []int{1, 2}, 3) }, false},
+		{"ContainsKeys", func(t testing.TB) bool { return check.ContainsKeys(t, map[int]int{1: 2}, 1) }, true},
+		{"ContainsKeys fail", func(t testing.TB) bool { return check.ContainsKeys(t, map[int]int{1: 2}, 2) }, false},
+		{"ContainsVals", func(t testing.TB) bool { return check.ContainsVals(t, map[int]int{1: 2}, 2) }, true},
+		{"ContainsVals fail", func(t testing.TB) bool { return check.ContainsVals(t, map[int]int{1: 2}, 1) }, false},
+		{"Error", func(t testing.TB) bool { return check.Error(t, os.ErrNotExist) }, true},
+		{"Error fail", func(t testing.TB) bool { return check.Error(t, nil) }, false},
+		{"NilError", func(t testing.TB) bool { return check.NilError(t, nil) }, true},
+		{"NilError fail", func(t testing.TB) bool { return check.NilError(t, os.ErrNotExist) }, false},
+		{"ErrorIs", func(t testing.TB) bool { return check.ErrorIs(t, os.ErrNotExist, os.ErrNotExist) }, true},
+		{"ErrorIs fail", func(t testing.TB) bool { return check.ErrorIs(t, os.ErrNotExist, os.ErrExist) }, false},
+		{"InDelta", func(t testing.TB) bool { return check.InDelta(t, 0.1+0.2, 0.3, 1e-9) }, true},
+		{"InDelta fail", func(t testing.TB) bool { return check.InDelta(t, 1.0, 2, 0.5) }, false},
+		{"InEpsilonSlices", func(t testing.TB) bool { return check.InEpsilonSlices(t, []float64{101}, []float64{100}, 0.01) }, true},
+		{"InEpsilonSlices fail", func(t testing.TB) bool { return check.InEpsilonSlices(t, []float64{102}, []float64{100}, 0.01) }, false},
+		{"Between", func(t testing.TB) bool { return check.Between(t, 5, 1, 10) }, true},
+		{"Between fail", func(t testing.TB) bool { return check.Between(t, 0, 1, 10) }, false},
+		{"Sorted", func(t testing.TB) bool { return check.Sorted(t, []string{"a", "b"}) }, true},
+		{"Sorted fail", func(t testing.TB) bool { return check.Sorted(t, []string{"b", "a"}) }, false},
+		{"MatchesRegexp", func(t testing.TB) bool { return check.MatchesRegexp(t, "abc", "b") }, true},
+		{"MatchesRegexp fail", func(t testing.TB) bool { return check.MatchesRegexp(t, "abc", "d") }, false},
+		{"EqualLines", func(t testing.TB) bool { return check.EqualLines(t, "a\nb", "a\nb") }, true},
+		{"EqualLines fail", func(t testing.TB) bool { return check.EqualLines(t, "a\nb", "a\nc") }, false},
+		{"Panics", func(t testing.TB) bool { return check.Panics(t, func() { panic("boom") }) }, true},
+		{"Panics fail", func(t testing.TB) bool { return check.Panics(t, func() {}) }, false},
+		{"PanicsWithError", func(t testing.TB) bool { return check.PanicsWithError(t, func() { panic(os.ErrExist) }, os.ErrExist) }, true},
+		{"PanicsWithError fail", func(t testing.TB) bool { return check.PanicsWithError(t, func() { panic("boom") }, os.ErrExist) }, false},
+		{"Eventually", func(t testing.TB) bool {
+			return check.Eventually(t, func() bool { return true }, time.Second, time.Millisecond)
+		}, true},
+		{"Eventually fail", func(t testing.TB) bool {
+			return check.Eventually(t, func() bool { return false }, time.Millisecond, time.Millisecond)
+		}, false},
+		{"Never zero interval", func(t testing.TB) bool {
+			return check.Never(t, func() bool { return false }, 10*time.Millisecond, 0)
+		}, false},
+		{"EventuallyWithT fail", func(t testing.TB) bool {
+			return check.EventuallyWithT(t, func(c testing.TB) { check.Equal(c, 1, 2) }, time.Millisecond, time.Millisecond)
+		}, false},
+		{"ReceivesValue", func(t testing.TB) bool {
+			ch := make(chan int, 1)
+			ch <- 1
+			return check.ReceivesValue(t, ch, 1, time.Second)
+		}, true},
+		{"NotReceives fail", func(t testing.TB) bool {
+			ch := make(chan int)
+			close(ch)
+			return check.NotReceives(t, ch, time.Second)
+		}, false},
+		{"ErrorAs", func(t testing.TB) bool {
+			var pathErr *os.PathError
+			return check.ErrorAs(t, &os.PathError{}, &pathErr)
+		}, true},
+		{"ErrorAs fail", func(t testing.TB) bool {
+			var pathErr *os.PathError
+			return check.ErrorAs(t, errors.New(""), &pathErr)
+		}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			r := newRecorder(t)
+			if pass := tc.Check(r); pass != tc.Pass {
+				t.Fatalf("check returned %v, expected %v", pass, tc.Pass)
+			}
+
+			if r.fatalCalled {
+				t.Fatalf("fatal was called")
+			}
+
+			if tc.Pass && len(r.errors) != 0 {
+				t.Fatalf("unexpected errors: %v", r.errors)
+			}
+
+			if !tc.Pass && len(r.errors) != 1 {
+				t.Fatalf("expected exactly one error, got: %v", r.errors)
+			}
+		})
+	}
+}
+
+func TestChecksContinue(t *testing.T) {
+	r := newRecorder(t)
+	check.Equal(r, 1, 2)
+	check.Equal(r, "a", "b")
+	check.NilError(r, os.ErrNotExist)
+
+	if len(r.errors) != 3 {
+		t.Fatalf("expected 3 errors, got: %v", r.errors)
+	}
+}
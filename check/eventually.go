@@ -0,0 +1,48 @@
+package check
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zpatrick/testx/internal/assertion"
+)
+
+// Eventually calls t.Errorf if cond does not return true within timeout.
+// cond is called immediately, then once every interval.
+// A non-positive timeout or interval is reported as a failure.
+func Eventually(t testing.TB, cond func() bool, timeout, interval time.Duration) bool {
+	t.Helper()
+	return assertion.Eventually(t, t.Errorf, cond, timeout, interval)
+}
+
+// Consistently calls t.Errorf if cond returns false at any point during duration.
+// cond is called immediately, then once every interval.
+// A non-positive duration or interval is reported as a failure.
+func Consistently(t testing.TB, cond func() bool, duration, interval time.Duration) bool {
+	t.Helper()
+	return assertion.Consistently(t, t.Errorf, cond, duration, interval)
+}
+
+// Never calls t.Errorf if cond returns true at any point during duration.
+// cond is called immediately, then once every interval.
+// A non-positive duration or interval is reported as a failure.
+func Never(t testing.TB, cond func() bool, duration, interval time.Duration) bool {
+	t.Helper()
+	return assertion.Never(t, t.Errorf, cond, duration, interval)
+}
+
+// EventuallyWithT calls t.Errorf if cond does not complete without failing within timeout.
+// A non-positive timeout or interval is reported as a failure.
+// cond is called immediately, then once every interval, with a testing.TB which collects failures
+// instead of reporting them to t, so assertions made against it are retried, e.g.
+//
+//	check.EventuallyWithT(t, func(c testing.TB) {
+//		check.NilError(c, db.Ping())
+//	}, time.Minute, time.Second)
+//
+// Calling Fatal (or FailNow) on c ends the current attempt.
+// If timeout is reached, the failure message includes the failures of the last attempt.
+func EventuallyWithT(t testing.TB, cond func(c testing.TB), timeout, interval time.Duration) bool {
+	t.Helper()
+	return assertion.EventuallyWithT(t, t.Errorf, cond, timeout, interval)
+}
@@ -0,0 +1,66 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/zpatrick/testx/internal/assertion"
+)
+
+// Float is a constraint that permits any floating-point type.
+type Float = assertion.Float
+
+// InDelta calls t.Errorf if the absolute difference between result and expected is greater than delta.
+// NaN is considered equal to NaN, and infinities are equal to infinities of the same sign.
+func InDelta[T Float](t testing.TB, result, expected, delta T) bool {
+	t.Helper()
+	return assertion.InDelta(t, t.Errorf, result, expected, delta)
+}
+
+// InEpsilon calls t.Errorf if the relative error |result-expected|/|expected| is greater than epsilon.
+// NaN is considered equal to NaN, and infinities are equal to infinities of the same sign.
+// If expected is 0, result must also be 0.
+func InEpsilon[T Float](t testing.TB, result, expected, epsilon T) bool {
+	t.Helper()
+	return assertion.InEpsilon(t, t.Errorf, result, expected, epsilon)
+}
+
+// InDeltaSlices calls t.Errorf if result and expected are not the same length
+// or any pair of elements fails InDelta.
+func InDeltaSlices[T Float, TS ~[]T](t testing.TB, result, expected TS, delta T) bool {
+	t.Helper()
+	return assertion.InDeltaSlices(t, t.Errorf, []T(result), []T(expected), delta)
+}
+
+// InEpsilonSlices calls t.Errorf if result and expected are not the same length
+// or any pair of elements fails InEpsilon.
+func InEpsilonSlices[T Float, TS ~[]T](t testing.TB, result, expected TS, epsilon T) bool {
+	t.Helper()
+	return assertion.InEpsilonSlices(t, t.Errorf, []T(result), []T(expected), epsilon)
+}
+
+// InDeltaMaps calls t.Errorf if result and expected do not have the same keys
+// or the values of any key fail InDelta.
+func InDeltaMaps[K comparable, T Float](t testing.TB, result, expected map[K]T, delta T) bool {
+	t.Helper()
+	return assertion.InDeltaMaps(t, t.Errorf, result, expected, delta)
+}
+
+// InEpsilonMaps calls t.Errorf if result and expected do not have the same keys
+// or the values of any key fail InEpsilon.
+func InEpsilonMaps[K comparable, T Float](t testing.TB, result, expected map[K]T, epsilon T) bool {
+	t.Helper()
+	return assertion.InEpsilonMaps(t, t.Errorf, result, expected, epsilon)
+}
+
+// IsNaN calls t.Errorf if v is not NaN.
+func IsNaN[T Float](t testing.TB, v T) bool {
+	t.Helper()
+	return assertion.IsNaN(t, t.Errorf, v)
+}
+
+// IsInf calls t.Errorf if v is not an infinity with the given sign.
+// As with math.IsInf, a sign > 0 requires +Inf, a sign < 0 requires -Inf, and 0 allows either.
+func IsInf[T Float](t testing.TB, v T, sign int) bool {
+	t.Helper()
+	return assertion.IsInf(t, t.Errorf, v, sign)
+}
@@ -0,0 +1,55 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/zpatrick/testx/internal/assertion"
+)
+
+// Ordered is a constraint that permits any ordered type: any type that supports the operators < <= >= >.
+// It mirrors golang.org/x/exp/constraints.Ordered.
+type Ordered = assertion.Ordered
+
+// Greater calls t.Errorf unless result > threshold.
+func Greater[T Ordered](t testing.TB, result, threshold T) bool {
+	t.Helper()
+	return assertion.Greater(t, t.Errorf, result, threshold)
+}
+
+// GreaterOrEqual calls t.Errorf unless result >= threshold.
+func GreaterOrEqual[T Ordered](t testing.TB, result, threshold T) bool {
+	t.Helper()
+	return assertion.GreaterOrEqual(t, t.Errorf, result, threshold)
+}
+
+// Less calls t.Errorf unless result < threshold.
+func Less[T Ordered](t testing.TB, result, threshold T) bool {
+	t.Helper()
+	return assertion.Less(t, t.Errorf, result, threshold)
+}
+
+// LessOrEqual calls t.Errorf unless result <= threshold.
+func LessOrEqual[T Ordered](t testing.TB, result, threshold T) bool {
+	t.Helper()
+	return assertion.LessOrEqual(t, t.Errorf, result, threshold)
+}
+
+// Between calls t.Errorf unless min <= result <= max.
+func Between[T Ordered](t testing.TB, result, min, max T) bool {
+	t.Helper()
+	return assertion.Between(t, t.Errorf, result, min, max)
+}
+
+// Sorted calls t.Errorf unless s is sorted in ascending order.
+// The failure message shows the first pair of elements which are out of order.
+func Sorted[T Ordered, TS ~[]T](t testing.TB, s TS) bool {
+	t.Helper()
+	return assertion.Sorted(t, t.Errorf, []T(s))
+}
+
+// SortedFunc calls t.Errorf unless s is sorted according to less.
+// The failure message shows the first pair of elements which are out of order.
+func SortedFunc[T any, TS ~[]T](t testing.TB, s TS, less func(a, b T) bool) bool {
+	t.Helper()
+	return assertion.SortedFunc(t, t.Errorf, []T(s), less)
+}
@@ -0,0 +1,41 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/zpatrick/testx/internal/assertion"
+)
+
+// Panics calls t.Errorf if fn does not panic.
+func Panics(t testing.TB, fn func()) bool {
+	t.Helper()
+	return assertion.Panics(t, t.Errorf, fn)
+}
+
+// NotPanics calls t.Errorf if fn panics.
+// The failure message includes the recovered value and the stack trace of the panic.
+func NotPanics(t testing.TB, fn func()) bool {
+	t.Helper()
+	return assertion.NotPanics(t, t.Errorf, fn)
+}
+
+// PanicsWithValue calls t.Errorf if fn does not panic with a value equal to expected.
+// The failure message includes the recovered value and the stack trace of the panic.
+func PanicsWithValue[T comparable](t testing.TB, fn func(), expected T) bool {
+	t.Helper()
+	return assertion.PanicsWithValue(t, t.Errorf, fn, expected)
+}
+
+// PanicsWithError calls t.Errorf if fn does not panic with an error for which errors.Is(err, target) succeeds.
+// The failure message includes the recovered value and the stack trace of the panic.
+func PanicsWithError(t testing.TB, fn func(), target error) bool {
+	t.Helper()
+	return assertion.PanicsWithError(t, t.Errorf, fn, target)
+}
+
+// PanicsWithErrorAs calls t.Errorf if fn does not panic with an error for which errors.As(err, target) succeeds.
+// The failure message includes the recovered value and the stack trace of the panic.
+func PanicsWithErrorAs(t testing.TB, fn func(), target any) bool {
+	t.Helper()
+	return assertion.PanicsWithErrorAs(t, t.Errorf, fn, target)
+}
@@ -0,0 +1,45 @@
+package check
+
+import (
+	"testing"
+
+	"github.com/zpatrick/testx/internal/assertion"
+)
+
+// StringContains calls t.Errorf if s does not contain substr.
+func StringContains(t testing.TB, s, substr string) bool {
+	t.Helper()
+	return assertion.StringContains(t, t.Errorf, s, substr)
+}
+
+// HasPrefix calls t.Errorf if s does not begin with prefix.
+func HasPrefix(t testing.TB, s, prefix string) bool {
+	t.Helper()
+	return assertion.HasPrefix(t, t.Errorf, s, prefix)
+}
+
+// HasSuffix calls t.Errorf if s does not end with suffix.
+func HasSuffix(t testing.TB, s, suffix string) bool {
+	t.Helper()
+	return assertion.HasSuffix(t, t.Errorf, s, suffix)
+}
+
+// MatchesRegexp calls t.Errorf if s does not match the regular expression pattern,
+// or if pattern cannot be compiled.
+func MatchesRegexp(t testing.TB, s, pattern string) bool {
+	t.Helper()
+	return assertion.MatchesRegexp(t, t.Errorf, s, pattern)
+}
+
+// EqualFold calls t.Errorf if result and expected are not equal under Unicode case-folding.
+func EqualFold(t testing.TB, result, expected string) bool {
+	t.Helper()
+	return assertion.EqualFold(t, t.Errorf, result, expected)
+}
+
+// EqualLines calls t.Errorf if result != expected.
+// The failure message points to the first differing line and column, followed by a line-level diff.
+func EqualLines(t testing.TB, result, expected string) bool {
+	t.Helper()
+	return assertion.EqualLines(t, t.Errorf, result, expected)
+}
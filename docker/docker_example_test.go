@@ -10,6 +10,7 @@ import (
 	"log"
 	"net"
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -25,18 +26,15 @@ import (
 )
 
 type MysqlSuite struct {
+	Port     int    `testx:"env=APP_DB_PORT,flag=mysql.port,default=3306"`
+	Password string `testx:"env=APP_DB_PASSWORD,default=pswd123"`
+	Database string `testx:"env=APP_DB_NAME,default=users"`
+
 	containerID string
 	db          *sql.DB
 }
 
-// var mysqlPort = cfg.Setting[int]{
-// 	Default: func() int { return 3306 },
-// 	Providers: []cfg.Provider[int]{
-// 		cfg.EnvVar("APP_DB_PORT", strconv.Atoi),
-// 	},
-// }
-
-func (m *MysqlSuite) Setup() error {
+func (m *MysqlSuite) Setup(tb testing.TB) error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
 	defer cancel()
 
@@ -45,6 +43,10 @@ func (m *MysqlSuite) Setup() error {
 		return errors.Wrap(err, "failed to start docker client")
 	}
 
+	if _, err := dockerClient.Ping(ctx); err != nil {
+		return suite.Unavailablef("docker daemon is not reachable: %s", err.Error())
+	}
+
 	// TODO: pull image if not exists
 	resp, err := dockerClient.ContainerCreate(
 		ctx,
@@ -52,13 +54,13 @@ func (m *MysqlSuite) Setup() error {
 			Image:        "mysql",
 			ExposedPorts: nat.PortSet{"3306": struct{}{}},
 			Env: []string{
-				"MYSQL_ROOT_PASSWORD=pswd123",
-				"MYSQL_DATABASE=users",
+				"MYSQL_ROOT_PASSWORD=" + m.Password,
+				"MYSQL_DATABASE=" + m.Database,
 			},
 		},
 		&container.HostConfig{
 			PortBindings: map[nat.Port][]nat.PortBinding{
-				nat.Port("3306"): {{HostIP: "127.0.0.1", HostPort: "3306"}},
+				nat.Port("3306"): {{HostIP: "127.0.0.1", HostPort: strconv.Itoa(m.Port)}},
 			},
 		},
 		nil,
@@ -74,27 +76,28 @@ func (m *MysqlSuite) Setup() error {
 		return errors.Wrap(err, "failed to start mysql docker container")
 	}
 
+	addr := net.JoinHostPort("0.0.0.0", strconv.Itoa(m.Port))
 	for d := time.Millisecond * 500; ; d += time.Millisecond * 500 {
-		conn, err := net.DialTimeout("tcp", "0.0.0.0:3306", time.Millisecond*50)
+		conn, err := net.DialTimeout("tcp", addr, time.Millisecond*50)
 		if err != nil {
 			if errors.Is(err, io.EOF) || strings.Contains(err.Error(), "connection refused") {
-				log.Printf("waiting for port 3306 to become available (error: %s)", err.Error())
+				log.Printf("waiting for port %d to become available (error: %s)", m.Port, err.Error())
 				time.Sleep(d)
 				continue
 			}
 
-			return errors.Wrap(err, "failed to dial 3306")
+			return errors.Wrapf(err, "failed to dial %d", m.Port)
 		}
 		defer conn.Close()
 
 		if _, _, err := bufio.NewReader(conn).ReadLine(); err != nil {
 			if errors.Is(err, io.EOF) || strings.Contains(err.Error(), "connection refused") {
-				log.Printf("waiting for port 3306 to become available (error: %s)", err.Error())
+				log.Printf("waiting for port %d to become available (error: %s)", m.Port, err.Error())
 				time.Sleep(d)
 				continue
 			}
 
-			return errors.Wrap(err, "failed to read 3306")
+			return errors.Wrapf(err, "failed to read %d", m.Port)
 		}
 
 		break
@@ -102,10 +105,10 @@ func (m *MysqlSuite) Setup() error {
 
 	cfg := mysql.Config{
 		User:   "root",
-		Passwd: "pswd123",
+		Passwd: m.Password,
 		Net:    "tcp",
-		Addr:   "0.0.0.0:3306",
-		DBName: "users",
+		Addr:   addr,
+		DBName: m.Database,
 	}
 
 	db, err := sql.Open("mysql", cfg.FormatDSN())
@@ -179,5 +182,5 @@ func TestMysql_doSomeQuery(t *testing.T) {
 
 	var dbName string
 	assert.NilError(t, row.Scan(&dbName))
-	assert.Equal(t, dbName, "users")
+	assert.Equal(t, dbName, m.Database)
 }
@@ -0,0 +1,210 @@
+// Package assertion implements the assertions shared by the assert and check packages.
+// Each assertion reports failures using the given Reporter and returns whether it passed.
+package assertion
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// A Reporter reports a failed assertion, e.g. t.Fatalf or t.Errorf.
+type Reporter func(format string, args ...any)
+
+// Equal reports a failure if result != expected.
+// If result and expected are multi-line strings, the failure message shows a line-level diff.
+func Equal[T comparable](t testing.TB, fail Reporter, result, expected T) bool {
+	t.Helper()
+
+	if result != expected {
+		if res, exp, ok := multiLineStrings(result, expected); ok {
+			fail("strings are not equal:\n%s", diffLines(res, exp))
+			return false
+		}
+
+		fail("%v != %v", result, expected)
+		return false
+	}
+
+	return true
+}
+
+// EqualSlices reports a failure if result and expected do not contain the same elements in the same order.
+// The failure message lists every differing index, followed by an element-level diff of the slices.
+func EqualSlices[T comparable](t testing.TB, fail Reporter, result, expected []T) bool {
+	t.Helper()
+
+	var diffs []string
+	if resLen, expLen := len(result), len(expected); resLen != expLen {
+		diffs = append(diffs, fmt.Sprintf("length: %d != %d", resLen, expLen))
+	}
+
+	for i := 0; i < len(result) || i < len(expected); i++ {
+		switch {
+		case i >= len(expected):
+			diffs = append(diffs, fmt.Sprintf("index %d: unexpected element %v", i, result[i]))
+		case i >= len(result):
+			diffs = append(diffs, fmt.Sprintf("index %d: missing element %v", i, expected[i]))
+		case result[i] != expected[i]:
+			diffs = append(diffs, fmt.Sprintf("index %d: %v != %v", i, result[i], expected[i]))
+		}
+	}
+
+	if len(diffs) == 0 {
+		return true
+	}
+
+	fail("slices are not equal:\n%s\n%s", indentLines(diffs), diffSlices(result, expected))
+	return false
+}
+
+// EqualMaps reports a failure if result and expected do not contain the same elements.
+// The failure message lists every missing, extra, and changed key, followed by a diff of the maps.
+func EqualMaps[K, V comparable](t testing.TB, fail Reporter, result, expected map[K]V) bool {
+	t.Helper()
+
+	var diffs []string
+	for _, k := range sortedMapKeys(result, expected) {
+		expVal, inExp := expected[k]
+		resVal, inRes := result[k]
+		switch {
+		case !inRes:
+			diffs = append(diffs, fmt.Sprintf("key %v: missing (expected %v)", k, expVal))
+		case !inExp:
+			diffs = append(diffs, fmt.Sprintf("key %v: unexpected %v", k, resVal))
+		case resVal != expVal:
+			diffs = append(diffs, fmt.Sprintf("key %v: %v != %v", k, resVal, expVal))
+		}
+	}
+
+	if len(diffs) == 0 {
+		return true
+	}
+
+	fail("maps are not equal:\n%s\n%s", indentLines(diffs), diffMaps(result, expected))
+	return false
+}
+
+// indentLines joins lines into an indented block.
+func indentLines(lines []string) string {
+	return "  " + strings.Join(lines, "\n  ")
+}
+
+// EqualC reports a failure if !result.Equal(expected).
+func EqualC[T any](t testing.TB, fail Reporter, result interface{ Equal(t T) bool }, expected T) bool {
+	t.Helper()
+
+	if !result.Equal(expected) {
+		fail("%v != %v", result, expected)
+		return false
+	}
+
+	return true
+}
+
+// Contains reports a failure if any value v is not present in s.
+func Contains[T comparable](t testing.TB, fail Reporter, s []T, v ...T) bool {
+	t.Helper()
+
+	for _, expected := range v {
+		var found bool
+		for _, actual := range s {
+			if expected == actual {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			fail("%v not present in %v", expected, s)
+			return false
+		}
+	}
+
+	return true
+}
+
+// ContainsKeys reports a failure if any of the specified keys are not present in m.
+func ContainsKeys[T comparable, A any](t testing.TB, fail Reporter, m map[T]A, keys ...T) bool {
+	t.Helper()
+
+	for _, key := range keys {
+		if _, ok := m[key]; !ok {
+			fail("key %v not present in %v", key, m)
+			return false
+		}
+	}
+
+	return true
+}
+
+// ContainsVals reports a failure if any of the specified vals are not present in m.
+func ContainsVals[A, T comparable](t testing.TB, fail Reporter, m map[A]T, vals ...T) bool {
+	t.Helper()
+
+	for _, expected := range vals {
+		var found bool
+		for _, actual := range m {
+			if expected == actual {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			fail("val %v not present in %v", expected, m)
+			return false
+		}
+	}
+
+	return true
+}
+
+// Error reports a failure if err is nil.
+func Error(t testing.TB, fail Reporter, err error) bool {
+	t.Helper()
+
+	if err == nil {
+		fail("error is nil")
+		return false
+	}
+
+	return true
+}
+
+// NilError reports a failure if err is not nil.
+func NilError(t testing.TB, fail Reporter, err error) bool {
+	t.Helper()
+
+	if err != nil {
+		fail("error is not nil: %v", err)
+		return false
+	}
+
+	return true
+}
+
+// ErrorIs reports a failure if errors.Is(err, target) fails.
+func ErrorIs(t testing.TB, fail Reporter, err, target error) bool {
+	t.Helper()
+
+	if !errors.Is(err, target) {
+		fail("error.Is check failed for %v (target: %v)", err, target)
+		return false
+	}
+
+	return true
+}
+
+// ErrorAs reports a failure if errors.As(err, target) fails.
+func ErrorAs(t testing.TB, fail Reporter, err error, target any) bool {
+	t.Helper()
+
+	if !errors.As(err, target) {
+		fail("error.As check failed for %v (target: %v)", err, target)
+		return false
+	}
+
+	return true
+}
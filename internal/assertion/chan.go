@@ -0,0 +1,138 @@
+package assertion
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Receives reports a failure if a value is not received from ch within timeout.
+// It returns the received value, if any.
+// Like the other channel assertions, it receives a value which is already waiting in ch even if timeout is 0.
+func Receives[T any](t testing.TB, fail Reporter, ch <-chan T, timeout time.Duration) (T, bool) {
+	t.Helper()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	v, ok, received := receive(ch, timer)
+	switch {
+	case !received:
+		fail("no value received within %v", timeout)
+		return v, false
+	case !ok:
+		fail("channel was closed before a value was received")
+		return v, false
+	}
+
+	return v, true
+}
+
+// ReceivesValue reports a failure if a value equal to expected is not received from ch within timeout.
+func ReceivesValue[T comparable](t testing.TB, fail Reporter, ch <-chan T, expected T, timeout time.Duration) bool {
+	t.Helper()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	v, ok, received := receive(ch, timer)
+	switch {
+	case !received:
+		fail("no value received within %v (expected %v)", timeout, expected)
+		return false
+	case !ok:
+		fail("channel was closed before a value was received (expected %v)", expected)
+		return false
+	case v != expected:
+		fail("received %v != %v", v, expected)
+		return false
+	}
+
+	return true
+}
+
+// NotReceives reports a failure if a value is received from ch, or ch is closed, within d.
+func NotReceives[T any](t testing.TB, fail Reporter, ch <-chan T, d time.Duration) bool {
+	t.Helper()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	v, ok, received := receive(ch, timer)
+	switch {
+	case !received:
+		return true
+	case !ok:
+		fail("channel was closed within %v", d)
+		return false
+	}
+
+	fail("received %v within %v", v, d)
+	return false
+}
+
+// Closed reports a failure if ch is not closed within timeout, or if a value is received from it instead.
+func Closed[T any](t testing.TB, fail Reporter, ch <-chan T, timeout time.Duration) bool {
+	t.Helper()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	v, ok, received := receive(ch, timer)
+	switch {
+	case !received:
+		fail("channel was not closed within %v", timeout)
+		return false
+	case ok:
+		fail("received %v instead of channel close", v)
+		return false
+	}
+
+	return true
+}
+
+// Drains reports a failure if the values received from ch before it is closed are not equal to expected,
+// or if ch is not closed within timeout.
+func Drains[T comparable](t testing.TB, fail Reporter, ch <-chan T, expected []T, timeout time.Duration) bool {
+	t.Helper()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	values := []T{}
+	for {
+		v, ok, received := receive(ch, timer)
+		switch {
+		case !received:
+			fail("channel was not closed within %v; received %d values: %v (expected %v)", timeout, len(values), values, expected)
+			return false
+		case ok:
+			values = append(values, v)
+			continue
+		}
+
+		return EqualSlices(t, func(format string, args ...any) {
+			t.Helper()
+			fail("values received before channel close are not equal to expected:\n%s", fmt.Sprintf(format, args...))
+		}, values, expected)
+	}
+}
+
+// receive receives from ch, waiting until timer fires if no value is ready.
+// A value (or close) which is already ready is always received, even if timer has fired,
+// since select chooses randomly between ready cases.
+// The received bool is false if timer fired before ch was ready.
+func receive[T any](ch <-chan T, timer *time.Timer) (v T, ok, received bool) {
+	select {
+	case v, ok = <-ch:
+		return v, ok, true
+	default:
+	}
+
+	select {
+	case v, ok = <-ch:
+		return v, ok, true
+	case <-timer.C:
+		return v, false, false
+	}
+}
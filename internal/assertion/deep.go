@@ -0,0 +1,349 @@
+package assertion
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"unsafe"
+)
+
+// defaultMaxDiffs is the default number of mismatches reported by DeepEqual.
+const defaultMaxDiffs = 10
+
+// A DeepOption configures how DeepEqual compares values.
+type DeepOption func(*deepConfig)
+
+type deepConfig struct {
+	ignoreFields     map[string]bool
+	ignoreUnexported bool
+	nilEqualsEmpty   bool
+	sorters          map[reflect.Type]func(a, b reflect.Value) bool
+	comparators      map[reflect.Type]func(a, b reflect.Value) bool
+	maxDiffs         int
+}
+
+// IgnoreFields skips struct fields with the given names.
+// A name can either be a bare field name (e.g. "UpdatedAt"), which matches the field in any struct type,
+// or qualified by its struct type's name (e.g. "User.UpdatedAt").
+func IgnoreFields(names ...string) DeepOption {
+	return func(c *deepConfig) {
+		for _, name := range names {
+			c.ignoreFields[name] = true
+		}
+	}
+}
+
+// IgnoreUnexported skips unexported struct fields.
+func IgnoreUnexported() DeepOption {
+	return func(c *deepConfig) {
+		c.ignoreUnexported = true
+	}
+}
+
+// NilEqualsEmpty treats nil slices and maps as equal to empty ones.
+func NilEqualsEmpty() DeepOption {
+	return func(c *deepConfig) {
+		c.nilEqualsEmpty = true
+	}
+}
+
+// SortSlices sorts slices (and arrays) of E using less before comparing them,
+// so the order of their elements is ignored. It also applies to values reached through unexported fields.
+func SortSlices[E any](less func(a, b E) bool) DeepOption {
+	return func(c *deepConfig) {
+		c.sorters[typeOf[E]()] = func(a, b reflect.Value) bool {
+			return less(a.Interface().(E), b.Interface().(E))
+		}
+	}
+}
+
+// Comparator compares values of type T using equal instead of walking them.
+// It also applies to values reached through unexported fields.
+func Comparator[T any](equal func(result, expected T) bool) DeepOption {
+	return func(c *deepConfig) {
+		c.comparators[typeOf[T]()] = func(a, b reflect.Value) bool {
+			return equal(a.Interface().(T), b.Interface().(T))
+		}
+	}
+}
+
+// MaxDiffs sets the maximum number of mismatches reported (10 by default).
+// Values less than 1 are treated as 1.
+func MaxDiffs(n int) DeepOption {
+	return func(c *deepConfig) {
+		if n < 1 {
+			n = 1
+		}
+
+		c.maxDiffs = n
+	}
+}
+
+// DeepEqual reports a failure if result and expected are not deeply equal.
+// The failure message lists the path (e.g. ".Users[0].Name") of each mismatch found, up to the limit set by MaxDiffs.
+func DeepEqual[T any](t testing.TB, fail Reporter, result, expected T, opts ...DeepOption) bool {
+	t.Helper()
+
+	cfg := deepConfig{
+		ignoreFields: map[string]bool{},
+		sorters:      map[reflect.Type]func(a, b reflect.Value) bool{},
+		comparators:  map[reflect.Type]func(a, b reflect.Value) bool{},
+		maxDiffs:     defaultMaxDiffs,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	w := &deepWalker{cfg: cfg, visited: map[deepVisit]bool{}}
+	w.compare("", reflect.ValueOf(&result).Elem(), reflect.ValueOf(&expected).Elem())
+	if w.mismatches == 0 {
+		return true
+	}
+
+	msg := strings.Join(w.diffs, "\n")
+	if w.truncated {
+		msg += "\n... (more differences omitted)"
+	}
+
+	fail("values are not deeply equal:\n%s", msg)
+	return false
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+type deepVisit struct {
+	res, exp uintptr
+	typ      reflect.Type
+}
+
+type deepWalker struct {
+	cfg        deepConfig
+	mismatches int
+	diffs      []string
+	truncated  bool
+	visited    map[deepVisit]bool
+}
+
+// report records a mismatch, describing it only while fewer than cfg.maxDiffs mismatches have been described.
+func (w *deepWalker) report(path, format string, args ...any) {
+	w.mismatches++
+	if len(w.diffs) >= w.cfg.maxDiffs {
+		w.truncated = true
+		return
+	}
+
+	if path == "" {
+		path = "(root)"
+	}
+
+	w.diffs = append(w.diffs, path+": "+fmt.Sprintf(format, args...))
+}
+
+// done returns true once a mismatch beyond the reporting limit has been found,
+// at which point there is nothing left to learn from walking the values.
+func (w *deepWalker) done() bool {
+	return w.truncated
+}
+
+func (w *deepWalker) compare(path string, res, exp reflect.Value) {
+	if w.done() {
+		return
+	}
+
+	if !res.IsValid() || !exp.IsValid() {
+		if res.IsValid() != exp.IsValid() {
+			w.report(path, "%v != %v", res, exp)
+		}
+
+		return
+	}
+
+	if res.Type() != exp.Type() {
+		w.report(path, "type %v != type %v", res.Type(), exp.Type())
+		return
+	}
+
+	if equal, ok := w.cfg.comparators[res.Type()]; ok {
+		if !equal(res, exp) {
+			w.report(path, "%v != %v (using comparator)", res, exp)
+		}
+
+		return
+	}
+
+	switch res.Kind() {
+	case reflect.Ptr:
+		if res.IsNil() || exp.IsNil() {
+			if res.IsNil() != exp.IsNil() {
+				w.report(path, "%v != %v", res, exp)
+			}
+
+			return
+		}
+
+		v := deepVisit{res: res.Pointer(), exp: exp.Pointer(), typ: res.Type()}
+		if res.Pointer() == exp.Pointer() || w.visited[v] {
+			return
+		}
+
+		w.visited[v] = true
+		w.compare(path, res.Elem(), exp.Elem())
+	case reflect.Interface:
+		if res.IsNil() || exp.IsNil() {
+			if res.IsNil() != exp.IsNil() {
+				w.report(path, "%v != %v", res, exp)
+			}
+
+			return
+		}
+
+		w.compare(path, res.Elem(), exp.Elem())
+	case reflect.Struct:
+		res, exp = addressable(res), addressable(exp)
+		t := res.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if w.cfg.ignoreFields[f.Name] || w.cfg.ignoreFields[t.Name()+"."+f.Name] {
+				continue
+			}
+
+			if !f.IsExported() && w.cfg.ignoreUnexported {
+				continue
+			}
+
+			w.compare(path+"."+f.Name, exported(res.Field(i)), exported(exp.Field(i)))
+		}
+	case reflect.Slice, reflect.Array:
+		if res.Kind() == reflect.Slice && res.IsNil() != exp.IsNil() && !w.cfg.nilEqualsEmpty {
+			w.report(path, "%s != %s", describeNil(res), describeNil(exp))
+			return
+		}
+
+		if res.Len() != exp.Len() {
+			w.report(path, "length %d != length %d", res.Len(), exp.Len())
+		}
+
+		resIdx, expIdx := w.order(res), w.order(exp)
+		for i := 0; i < len(resIdx) && i < len(expIdx); i++ {
+			w.compare(fmt.Sprintf("%s[%d]", path, i), res.Index(resIdx[i]), exp.Index(expIdx[i]))
+		}
+	case reflect.Map:
+		if res.IsNil() != exp.IsNil() && !w.cfg.nilEqualsEmpty {
+			w.report(path, "%s != %s", describeNil(res), describeNil(exp))
+			return
+		}
+
+		for _, k := range sortedKeys(exp) {
+			resVal := res.MapIndex(k)
+			if !resVal.IsValid() {
+				w.report(fmt.Sprintf("%s[%v]", path, k), "missing from result (expected %v)", exp.MapIndex(k))
+				continue
+			}
+
+			w.compare(fmt.Sprintf("%s[%v]", path, k), resVal, exp.MapIndex(k))
+		}
+
+		for _, k := range sortedKeys(res) {
+			if !exp.MapIndex(k).IsValid() {
+				w.report(fmt.Sprintf("%s[%v]", path, k), "unexpected in result (%v)", res.MapIndex(k))
+			}
+		}
+	case reflect.Func:
+		if !res.IsNil() || !exp.IsNil() {
+			w.report(path, "non-nil funcs cannot be compared")
+		}
+	case reflect.Chan, reflect.UnsafePointer:
+		if res.Pointer() != exp.Pointer() {
+			w.report(path, "%v != %v", res, exp)
+		}
+	case reflect.Bool:
+		if res.Bool() != exp.Bool() {
+			w.report(path, "%v != %v", res, exp)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if res.Int() != exp.Int() {
+			w.report(path, "%v != %v", res, exp)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if res.Uint() != exp.Uint() {
+			w.report(path, "%v != %v", res, exp)
+		}
+	case reflect.Float32, reflect.Float64:
+		if res.Float() != exp.Float() {
+			w.report(path, "%v != %v", res, exp)
+		}
+	case reflect.Complex64, reflect.Complex128:
+		if res.Complex() != exp.Complex() {
+			w.report(path, "%v != %v", res, exp)
+		}
+	case reflect.String:
+		if res.String() != exp.String() {
+			w.report(path, "%q != %q", res.String(), exp.String())
+		}
+	}
+}
+
+// order returns the indexes of v's elements, sorted if a sorter was configured for v's element type.
+func (w *deepWalker) order(v reflect.Value) []int {
+	idx := make([]int, v.Len())
+	for i := range idx {
+		idx[i] = i
+	}
+
+	less, ok := w.cfg.sorters[v.Type().Elem()]
+	if !ok {
+		return idx
+	}
+
+	sort.SliceStable(idx, func(i, j int) bool {
+		return less(v.Index(idx[i]), v.Index(idx[j]))
+	})
+
+	return idx
+}
+
+// addressable returns v, copying it into a new value if it is not addressable (e.g. a map value),
+// so that exported can be used on its fields.
+func addressable(v reflect.Value) reflect.Value {
+	if v.CanAddr() {
+		return v
+	}
+
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
+
+// exported returns the struct field f such that it can be used with Interface, even if it is unexported.
+// Comparator and SortSlices need Interface to call their functions, and the values walked by compare
+// are only ever read, so this does not allow unexported fields to be modified.
+// f must be addressable; values derived from the result (e.g. its elements) can also be used with Interface.
+func exported(f reflect.Value) reflect.Value {
+	if f.CanInterface() {
+		return f
+	}
+
+	return reflect.NewAt(f.Type(), unsafe.Pointer(f.UnsafeAddr())).Elem()
+}
+
+func sortedKeys(m reflect.Value) []reflect.Value {
+	keys := m.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+
+	return keys
+}
+
+func describeNil(v reflect.Value) string {
+	if v.IsNil() {
+		return "nil"
+	}
+
+	return fmt.Sprintf("%v", v)
+}
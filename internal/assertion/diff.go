@@ -0,0 +1,222 @@
+package assertion
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// diffContext is the number of unchanged entries shown around each change in a diff.
+const diffContext = 2
+
+// maxLCSCells bounds the size of the table used to compute an edit script.
+// Larger inputs are compared index by index instead.
+const maxLCSCells = 1 << 22
+
+type editKind int
+
+const (
+	editEqual editKind = iota
+	editDelete
+	editInsert
+)
+
+// An edit is a single step in an edit script which transforms expected into result.
+// exp and res index the expected and result entries the edit refers to.
+type edit struct {
+	kind     editKind
+	exp, res int
+}
+
+// diffEdits returns the edit script which transforms expected into result,
+// based on their longest common subsequence.
+func diffEdits[T comparable](expected, result []T) []edit {
+	n, m := len(expected), len(result)
+	if (n+1)*(m+1) > maxLCSCells {
+		return indexEdits(expected, result)
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of expected[i:] and result[j:].
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			switch {
+			case expected[i] == result[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	edits := make([]edit, 0, n+m)
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && expected[i] == result[j]:
+			edits = append(edits, edit{kind: editEqual, exp: i, res: j})
+			i, j = i+1, j+1
+		case i < n && (j == m || lcs[i+1][j] >= lcs[i][j+1]):
+			edits = append(edits, edit{kind: editDelete, exp: i, res: j})
+			i++
+		default:
+			edits = append(edits, edit{kind: editInsert, exp: i, res: j})
+			j++
+		}
+	}
+
+	return edits
+}
+
+// indexEdits returns an edit script which compares expected and result index by index.
+func indexEdits[T comparable](expected, result []T) []edit {
+	var edits []edit
+	for i := 0; i < len(expected) || i < len(result); i++ {
+		switch {
+		case i >= len(result):
+			edits = append(edits, edit{kind: editDelete, exp: i, res: i})
+		case i >= len(expected):
+			edits = append(edits, edit{kind: editInsert, exp: i, res: i})
+		case expected[i] == result[i]:
+			edits = append(edits, edit{kind: editEqual, exp: i, res: i})
+		default:
+			edits = append(edits,
+				edit{kind: editDelete, exp: i, res: i},
+				edit{kind: editInsert, exp: i, res: i},
+			)
+		}
+	}
+
+	return edits
+}
+
+// renderEdits formats an edit script as a unified diff.
+// Unchanged entries further than diffContext from a change are elided.
+func renderEdits(edits []edit, expLine func(i int) string, resLine func(j int) string) string {
+	show := make([]bool, len(edits))
+	for i, e := range edits {
+		if e.kind == editEqual {
+			continue
+		}
+
+		for j := i - diffContext; j <= i+diffContext; j++ {
+			if j >= 0 && j < len(edits) {
+				show[j] = true
+			}
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString("--- expected\n+++ result\n")
+
+	elided := false
+	for i, e := range edits {
+		if !show[i] {
+			if !elided {
+				sb.WriteString("  ...\n")
+				elided = true
+			}
+
+			continue
+		}
+
+		elided = false
+		switch e.kind {
+		case editEqual:
+			sb.WriteString("  " + expLine(e.exp) + "\n")
+		case editDelete:
+			sb.WriteString("- " + expLine(e.exp) + "\n")
+		case editInsert:
+			sb.WriteString("+ " + resLine(e.res) + "\n")
+		}
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// diffSlices returns an element-level diff between expected and result.
+func diffSlices[T comparable](result, expected []T) string {
+	return renderEdits(
+		diffEdits(expected, result),
+		func(i int) string { return fmt.Sprintf("[%d] %v", i, expected[i]) },
+		func(j int) string { return fmt.Sprintf("[%d] %v", j, result[j]) },
+	)
+}
+
+// diffMaps returns a diff of the keys which are missing, extra, or changed between expected and result.
+// Keys are sorted by their formatted value.
+func diffMaps[K, V comparable](result, expected map[K]V) string {
+	var sb strings.Builder
+	sb.WriteString("--- expected\n+++ result\n")
+	for _, k := range sortedMapKeys(result, expected) {
+		expVal, inExp := expected[k]
+		resVal, inRes := result[k]
+		if inExp && inRes && expVal == resVal {
+			continue
+		}
+
+		if inExp {
+			fmt.Fprintf(&sb, "- [%v] %v\n", k, expVal)
+		}
+
+		if inRes {
+			fmt.Fprintf(&sb, "+ [%v] %v\n", k, resVal)
+		}
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// sortedMapKeys returns the keys present in either result or expected, sorted by their formatted value.
+func sortedMapKeys[K, V comparable](result, expected map[K]V) []K {
+	keys := make([]K, 0, len(expected))
+	for k := range expected {
+		keys = append(keys, k)
+	}
+
+	for k := range result {
+		if _, ok := expected[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+
+	return keys
+}
+
+// diffLines returns a line-level diff between expected and result.
+func diffLines(result, expected string) string {
+	resLines := strings.Split(result, "\n")
+	expLines := strings.Split(expected, "\n")
+
+	return renderEdits(
+		diffEdits(expLines, resLines),
+		func(i int) string { return fmt.Sprintf("%4d | %s", i+1, expLines[i]) },
+		func(j int) string { return fmt.Sprintf("%4d | %s", j+1, resLines[j]) },
+	)
+}
+
+// multiLineStrings returns result and expected as strings if both are strings
+// and at least one of them spans multiple lines.
+func multiLineStrings(result, expected any) (string, string, bool) {
+	res, exp := reflect.ValueOf(result), reflect.ValueOf(expected)
+	if res.Kind() != reflect.String || exp.Kind() != reflect.String {
+		return "", "", false
+	}
+
+	if !strings.Contains(res.String(), "\n") && !strings.Contains(exp.String(), "\n") {
+		return "", "", false
+	}
+
+	return res.String(), exp.String(), true
+}
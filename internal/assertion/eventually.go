@@ -0,0 +1,273 @@
+package assertion
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Eventually reports a failure if cond does not return true within timeout.
+// cond is called immediately, then once every interval.
+func Eventually(t testing.TB, fail Reporter, cond func() bool, timeout, interval time.Duration) bool {
+	t.Helper()
+
+	if !validPolling(fail, "timeout", timeout, interval) {
+		return false
+	}
+
+	if ok, checks := poll(timeout, interval, cond); !ok {
+		fail("condition was not met within %v (checked %d times)", timeout, checks)
+		return false
+	}
+
+	return true
+}
+
+// Consistently reports a failure if cond returns false at any point during duration.
+// cond is called immediately, then once every interval.
+func Consistently(t testing.TB, fail Reporter, cond func() bool, duration, interval time.Duration) bool {
+	t.Helper()
+
+	if !validPolling(fail, "duration", duration, interval) {
+		return false
+	}
+
+	start := time.Now()
+	if failed, checks := poll(duration, interval, func() bool { return !cond() }); failed {
+		fail("condition was not met on check %d, after %v", checks, time.Since(start).Round(time.Millisecond))
+		return false
+	}
+
+	return true
+}
+
+// Never reports a failure if cond returns true at any point during duration.
+// cond is called immediately, then once every interval.
+func Never(t testing.TB, fail Reporter, cond func() bool, duration, interval time.Duration) bool {
+	t.Helper()
+
+	if !validPolling(fail, "duration", duration, interval) {
+		return false
+	}
+
+	start := time.Now()
+	if met, checks := poll(duration, interval, cond); met {
+		fail("condition was met on check %d, after %v", checks, time.Since(start).Round(time.Millisecond))
+		return false
+	}
+
+	return true
+}
+
+// EventuallyWithT reports a failure if cond does not complete without failing within timeout.
+// Each attempt passes cond a testing.TB which collects failures instead of reporting them to t,
+// so assertions made within cond are retried. If timeout is reached, the failures from the last attempt are reported.
+func EventuallyWithT(t testing.TB, fail Reporter, cond func(c testing.TB), timeout, interval time.Duration) bool {
+	t.Helper()
+
+	if !validPolling(fail, "timeout", timeout, interval) {
+		return false
+	}
+
+	var last *collectT
+	ok, checks := poll(timeout, interval, func() bool {
+		last = newCollectT(t)
+		last.run(cond)
+		return !last.Failed()
+	})
+	if !ok {
+		fail("condition was not met within %v (checked %d times); last attempt failed with:\n%s",
+			timeout, checks, indentLines(last.failures()))
+		return false
+	}
+
+	return true
+}
+
+// validPolling reports a failure unless both the polling period (named name) and interval are positive.
+func validPolling(fail Reporter, name string, period, interval time.Duration) bool {
+	switch {
+	case period <= 0:
+		fail("invalid %s %v: must be positive", name, period)
+		return false
+	case interval <= 0:
+		fail("invalid interval %v: must be positive", interval)
+		return false
+	}
+
+	return true
+}
+
+// poll calls attempt immediately, then once every interval, until it returns true or timeout is reached.
+// It returns whether attempt returned true, and the number of times it was called.
+// An attempt which is running when timeout is reached is allowed to finish.
+func poll(timeout, interval time.Duration, attempt func() bool) (bool, int) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for checks := 1; ; checks++ {
+		if attempt() {
+			return true, checks
+		}
+
+		select {
+		case <-timer.C:
+			return false, checks
+		case <-ticker.C:
+		}
+	}
+}
+
+// A collectT is the testing.TB passed to the condition of EventuallyWithT.
+// It records failures instead of reporting them; FailNow (and Fatal, Fatalf) end the current attempt.
+type collectT struct {
+	// The parent test provides the remaining testing.TB methods, such as Name and TempDir.
+	testing.TB
+
+	mux      sync.Mutex
+	messages []string
+	failed   bool
+	cleanups []func()
+}
+
+func newCollectT(parent testing.TB) *collectT {
+	return &collectT{TB: parent}
+}
+
+// run calls cond with c in a new goroutine, so FailNow can end the attempt using runtime.Goexit.
+func (c *collectT) run(cond func(c testing.TB)) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer c.runCleanups()
+
+		cond(c)
+	}()
+
+	<-done
+}
+
+func (c *collectT) runCleanups() {
+	c.mux.Lock()
+	cleanups := c.cleanups
+	c.cleanups = nil
+	c.mux.Unlock()
+
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+}
+
+func (c *collectT) failures() []string {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	if len(c.messages) == 0 {
+		return []string{"(no failure message)"}
+	}
+
+	return append([]string{}, c.messages...)
+}
+
+// Cleanup registers fn to be called once the current attempt has finished.
+func (c *collectT) Cleanup(fn func()) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.cleanups = append(c.cleanups, fn)
+}
+
+// Error records args as a failure.
+func (c *collectT) Error(args ...any) {
+	c.Log(args...)
+	c.Fail()
+}
+
+// Errorf records the formatted message as a failure.
+func (c *collectT) Errorf(format string, args ...any) {
+	c.Logf(format, args...)
+	c.Fail()
+}
+
+// Fail marks the current attempt as failed.
+func (c *collectT) Fail() {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.failed = true
+}
+
+// FailNow marks the current attempt as failed and ends it.
+func (c *collectT) FailNow() {
+	c.Fail()
+	runtime.Goexit()
+}
+
+// Failed reports whether the current attempt has failed.
+func (c *collectT) Failed() bool {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return c.failed
+}
+
+// Fatal records args as a failure and ends the current attempt.
+func (c *collectT) Fatal(args ...any) {
+	c.Log(args...)
+	c.FailNow()
+}
+
+// Fatalf records the formatted message as a failure and ends the current attempt.
+func (c *collectT) Fatalf(format string, args ...any) {
+	c.Logf(format, args...)
+	c.FailNow()
+}
+
+// Helper is a no-op: failures are reported by EventuallyWithT's caller.
+func (c *collectT) Helper() {}
+
+// Log records args; they are reported only if the attempt is the last one and it fails.
+func (c *collectT) Log(args ...any) {
+	c.record(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
+// Logf records the formatted message; it is reported only if the attempt is the last one and it fails.
+func (c *collectT) Logf(format string, args ...any) {
+	c.record(fmt.Sprintf(format, args...))
+}
+
+// Skip records args and ends the current attempt as a failure: a condition cannot skip the test.
+func (c *collectT) Skip(args ...any) {
+	c.Log(args...)
+	c.SkipNow()
+}
+
+// SkipNow ends the current attempt as a failure.
+func (c *collectT) SkipNow() {
+	c.record("condition called SkipNow")
+	c.FailNow()
+}
+
+// Skipf records the formatted message and ends the current attempt as a failure.
+func (c *collectT) Skipf(format string, args ...any) {
+	c.Logf(format, args...)
+	c.SkipNow()
+}
+
+// Skipped always returns false.
+func (c *collectT) Skipped() bool {
+	return false
+}
+
+func (c *collectT) record(msg string) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.messages = append(c.messages, strings.ReplaceAll(msg, "\n", "\n  "))
+}
@@ -0,0 +1,213 @@
+package assertion
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// Float is a constraint that permits any floating-point type.
+type Float interface {
+	~float32 | ~float64
+}
+
+// InDelta reports a failure if the absolute difference between result and expected is greater than delta.
+func InDelta[T Float](t testing.TB, fail Reporter, result, expected, delta T) bool {
+	t.Helper()
+
+	if msg := deltaMismatch(result, expected, delta); msg != "" {
+		fail("%s", msg)
+		return false
+	}
+
+	return true
+}
+
+// InEpsilon reports a failure if the relative error between result and expected is greater than epsilon.
+func InEpsilon[T Float](t testing.TB, fail Reporter, result, expected, epsilon T) bool {
+	t.Helper()
+
+	if msg := epsilonMismatch(result, expected, epsilon); msg != "" {
+		fail("%s", msg)
+		return false
+	}
+
+	return true
+}
+
+// InDeltaSlices reports a failure if result and expected are not the same length
+// or any pair of elements differ by more than delta.
+func InDeltaSlices[T Float](t testing.TB, fail Reporter, result, expected []T, delta T) bool {
+	t.Helper()
+
+	return floatSlices(t, fail, result, expected, func(r, e T) string { return deltaMismatch(r, e, delta) })
+}
+
+// InEpsilonSlices reports a failure if result and expected are not the same length
+// or any pair of elements have a relative error greater than epsilon.
+func InEpsilonSlices[T Float](t testing.TB, fail Reporter, result, expected []T, epsilon T) bool {
+	t.Helper()
+
+	return floatSlices(t, fail, result, expected, func(r, e T) string { return epsilonMismatch(r, e, epsilon) })
+}
+
+// InDeltaMaps reports a failure if result and expected do not have the same keys
+// or the values of any key differ by more than delta.
+func InDeltaMaps[K comparable, T Float](t testing.TB, fail Reporter, result, expected map[K]T, delta T) bool {
+	t.Helper()
+
+	return floatMaps(t, fail, result, expected, func(r, e T) string { return deltaMismatch(r, e, delta) })
+}
+
+// InEpsilonMaps reports a failure if result and expected do not have the same keys
+// or the values of any key have a relative error greater than epsilon.
+func InEpsilonMaps[K comparable, T Float](t testing.TB, fail Reporter, result, expected map[K]T, epsilon T) bool {
+	t.Helper()
+
+	return floatMaps(t, fail, result, expected, func(r, e T) string { return epsilonMismatch(r, e, epsilon) })
+}
+
+// IsNaN reports a failure if v is not NaN.
+func IsNaN[T Float](t testing.TB, fail Reporter, v T) bool {
+	t.Helper()
+
+	if !math.IsNaN(float64(v)) {
+		fail("%v is not NaN", v)
+		return false
+	}
+
+	return true
+}
+
+// IsInf reports a failure if v is not an infinity with the given sign, as defined by math.IsInf.
+func IsInf[T Float](t testing.TB, fail Reporter, v T, sign int) bool {
+	t.Helper()
+
+	if !math.IsInf(float64(v), sign) {
+		switch {
+		case sign > 0:
+			fail("%v is not +Inf", v)
+		case sign < 0:
+			fail("%v is not -Inf", v)
+		default:
+			fail("%v is not infinite", v)
+		}
+
+		return false
+	}
+
+	return true
+}
+
+func floatSlices[T Float](t testing.TB, fail Reporter, result, expected []T, mismatch func(result, expected T) string) bool {
+	t.Helper()
+
+	var diffs []string
+	if resLen, expLen := len(result), len(expected); resLen != expLen {
+		diffs = append(diffs, fmt.Sprintf("length: %d != %d", resLen, expLen))
+	}
+
+	for i := 0; i < len(result) && i < len(expected); i++ {
+		if msg := mismatch(result[i], expected[i]); msg != "" {
+			diffs = append(diffs, fmt.Sprintf("index %d: %s", i, msg))
+		}
+	}
+
+	if len(diffs) == 0 {
+		return true
+	}
+
+	fail("slices are not equal within tolerance:\n%s", indentLines(diffs))
+	return false
+}
+
+func floatMaps[K comparable, T Float](t testing.TB, fail Reporter, result, expected map[K]T, mismatch func(result, expected T) string) bool {
+	t.Helper()
+
+	var diffs []string
+	for _, k := range sortedMapKeys(result, expected) {
+		expVal, inExp := expected[k]
+		resVal, inRes := result[k]
+		switch {
+		case !inRes:
+			diffs = append(diffs, fmt.Sprintf("key %v: missing (expected %v)", k, expVal))
+		case !inExp:
+			diffs = append(diffs, fmt.Sprintf("key %v: unexpected %v", k, resVal))
+		default:
+			if msg := mismatch(resVal, expVal); msg != "" {
+				diffs = append(diffs, fmt.Sprintf("key %v: %s", k, msg))
+			}
+		}
+	}
+
+	if len(diffs) == 0 {
+		return true
+	}
+
+	fail("maps are not equal within tolerance:\n%s", indentLines(diffs))
+	return false
+}
+
+// deltaMismatch describes why result and expected differ by more than delta,
+// or returns an empty string if they do not.
+func deltaMismatch[T Float](result, expected, delta T) string {
+	if d := float64(delta); math.IsNaN(d) || d < 0 {
+		return fmt.Sprintf("invalid delta %v: must be a non-negative number", delta)
+	}
+
+	if msg, ok := nonFiniteMismatch(result, expected); ok {
+		return msg
+	}
+
+	if diff := math.Abs(float64(result) - float64(expected)); diff > float64(delta) {
+		return fmt.Sprintf("%v and %v differ by %v, more than delta %v", result, expected, T(diff), delta)
+	}
+
+	return ""
+}
+
+// epsilonMismatch describes why the relative error between result and expected is greater than epsilon,
+// or returns an empty string if it is not.
+func epsilonMismatch[T Float](result, expected, epsilon T) string {
+	if e := float64(epsilon); math.IsNaN(e) || e < 0 {
+		return fmt.Sprintf("invalid epsilon %v: must be a non-negative number", epsilon)
+	}
+
+	if msg, ok := nonFiniteMismatch(result, expected); ok {
+		return msg
+	}
+
+	if expected == 0 {
+		if result == 0 {
+			return ""
+		}
+
+		return fmt.Sprintf("relative error of %v is undefined when expected is 0", result)
+	}
+
+	relErr := math.Abs(float64(result)-float64(expected)) / math.Abs(float64(expected))
+	if relErr > float64(epsilon) {
+		return fmt.Sprintf("%v and %v have a relative error of %v, more than epsilon %v", result, expected, T(relErr), epsilon)
+	}
+
+	return ""
+}
+
+// nonFiniteMismatch compares result and expected if either is NaN or infinite.
+// NaN is considered equal to NaN, and infinities are equal to infinities of the same sign.
+// The returned bool is false if both values are finite and must be compared by the caller.
+func nonFiniteMismatch[T Float](result, expected T) (string, bool) {
+	res, exp := float64(result), float64(expected)
+	switch {
+	case math.IsNaN(res) && math.IsNaN(exp):
+		return "", true
+	case math.IsNaN(res) || math.IsNaN(exp), math.IsInf(res, 0) || math.IsInf(exp, 0):
+		if res == exp {
+			return "", true
+		}
+
+		return fmt.Sprintf("%v != %v", result, expected), true
+	}
+
+	return "", false
+}
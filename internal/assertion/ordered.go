@@ -0,0 +1,95 @@
+package assertion
+
+import (
+	"testing"
+)
+
+// Ordered is a constraint that permits any ordered type: any type that supports the operators < <= >= >.
+// It mirrors golang.org/x/exp/constraints.Ordered.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+// Greater reports a failure unless result > threshold.
+func Greater[T Ordered](t testing.TB, fail Reporter, result, threshold T) bool {
+	t.Helper()
+
+	if !(result > threshold) {
+		fail("%v is not greater than %v", result, threshold)
+		return false
+	}
+
+	return true
+}
+
+// GreaterOrEqual reports a failure unless result >= threshold.
+func GreaterOrEqual[T Ordered](t testing.TB, fail Reporter, result, threshold T) bool {
+	t.Helper()
+
+	if !(result >= threshold) {
+		fail("%v is not greater than or equal to %v", result, threshold)
+		return false
+	}
+
+	return true
+}
+
+// Less reports a failure unless result < threshold.
+func Less[T Ordered](t testing.TB, fail Reporter, result, threshold T) bool {
+	t.Helper()
+
+	if !(result < threshold) {
+		fail("%v is not less than %v", result, threshold)
+		return false
+	}
+
+	return true
+}
+
+// LessOrEqual reports a failure unless result <= threshold.
+func LessOrEqual[T Ordered](t testing.TB, fail Reporter, result, threshold T) bool {
+	t.Helper()
+
+	if !(result <= threshold) {
+		fail("%v is not less than or equal to %v", result, threshold)
+		return false
+	}
+
+	return true
+}
+
+// Between reports a failure unless min <= result <= max.
+func Between[T Ordered](t testing.TB, fail Reporter, result, min, max T) bool {
+	t.Helper()
+
+	if !(min <= result && result <= max) {
+		fail("%v is not between %v and %v (inclusive)", result, min, max)
+		return false
+	}
+
+	return true
+}
+
+// Sorted reports a failure unless s is sorted in ascending order.
+func Sorted[T Ordered](t testing.TB, fail Reporter, s []T) bool {
+	t.Helper()
+
+	return SortedFunc(t, fail, s, func(a, b T) bool { return a < b })
+}
+
+// SortedFunc reports a failure unless s is sorted according to less.
+func SortedFunc[T any](t testing.TB, fail Reporter, s []T, less func(a, b T) bool) bool {
+	t.Helper()
+
+	for i := 1; i < len(s); i++ {
+		if less(s[i], s[i-1]) {
+			fail("slice is not sorted: element %d (%v) is less than element %d (%v)", i, s[i], i-1, s[i-1])
+			return false
+		}
+	}
+
+	return true
+}
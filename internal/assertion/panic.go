@@ -0,0 +1,135 @@
+package assertion
+
+import (
+	"errors"
+	"runtime/debug"
+	"testing"
+)
+
+// Panics reports a failure if fn does not panic.
+func Panics(t testing.TB, fail Reporter, fn func()) bool {
+	t.Helper()
+
+	if p := capturePanic(fn); !p.panicked {
+		fail("function did not panic")
+		return false
+	}
+
+	return true
+}
+
+// NotPanics reports a failure if fn panics.
+func NotPanics(t testing.TB, fail Reporter, fn func()) bool {
+	t.Helper()
+
+	if p := capturePanic(fn); p.panicked {
+		fail("function panicked: %v\n%s", p.value, p.stack)
+		return false
+	}
+
+	return true
+}
+
+// PanicsWithValue reports a failure if fn does not panic with a value equal to expected.
+func PanicsWithValue[T comparable](t testing.TB, fail Reporter, fn func(), expected T) bool {
+	t.Helper()
+
+	p := capturePanic(fn)
+	if !p.panicked {
+		fail("function did not panic (expected panic value %v)", expected)
+		return false
+	}
+
+	v, ok := p.value.(T)
+	if !ok {
+		fail("panic value %v (%T) is not of type %T\n%s", p.value, p.value, expected, p.stack)
+		return false
+	}
+
+	if v != expected {
+		fail("panic value %v != %v\n%s", v, expected, p.stack)
+		return false
+	}
+
+	return true
+}
+
+// PanicsWithError reports a failure if fn does not panic with an error for which errors.Is(err, target) succeeds.
+func PanicsWithError(t testing.TB, fail Reporter, fn func(), target error) bool {
+	t.Helper()
+
+	p, err, ok := panicError(t, fail, fn, target)
+	if !ok {
+		return false
+	}
+
+	if !errors.Is(err, target) {
+		fail("error.Is check failed for panic value %v (target: %v)\n%s", err, target, p.stack)
+		return false
+	}
+
+	return true
+}
+
+// PanicsWithErrorAs reports a failure if fn does not panic with an error for which errors.As(err, target) succeeds.
+func PanicsWithErrorAs(t testing.TB, fail Reporter, fn func(), target any) bool {
+	t.Helper()
+
+	p, err, ok := panicError(t, fail, fn, target)
+	if !ok {
+		return false
+	}
+
+	if !errors.As(err, target) {
+		fail("error.As check failed for panic value %v (target: %v)\n%s", err, target, p.stack)
+		return false
+	}
+
+	return true
+}
+
+// panicError calls fn and returns the error it panicked with.
+// A failure is reported if fn does not panic, or panics with a value which is not an error.
+func panicError(t testing.TB, fail Reporter, fn func(), target any) (recovered, error, bool) {
+	t.Helper()
+
+	p := capturePanic(fn)
+	if !p.panicked {
+		fail("function did not panic (target: %v)", target)
+		return p, nil, false
+	}
+
+	err, ok := p.value.(error)
+	if !ok {
+		fail("panic value %v (%T) is not an error\n%s", p.value, p.value, p.stack)
+		return p, nil, false
+	}
+
+	return p, err, true
+}
+
+// recovered describes how a function exited.
+type recovered struct {
+	panicked bool
+	value    any
+	stack    []byte
+}
+
+// capturePanic calls fn, recovering from any panic it raises.
+// A panic with a nil value is still reported as a panic.
+func capturePanic(fn func()) (r recovered) {
+	completed := false
+	defer func() {
+		if !completed {
+			r = recovered{
+				panicked: true,
+				value:    recover(),
+				stack:    debug.Stack(),
+			}
+		}
+	}()
+
+	fn()
+	completed = true
+	return r
+}
@@ -0,0 +1,127 @@
+package assertion
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+// StringContains reports a failure if s does not contain substr.
+func StringContains(t testing.TB, fail Reporter, s, substr string) bool {
+	t.Helper()
+
+	if !strings.Contains(s, substr) {
+		fail("%q does not contain %q", s, substr)
+		return false
+	}
+
+	return true
+}
+
+// HasPrefix reports a failure if s does not begin with prefix.
+func HasPrefix(t testing.TB, fail Reporter, s, prefix string) bool {
+	t.Helper()
+
+	if !strings.HasPrefix(s, prefix) {
+		fail("%q does not have prefix %q", s, prefix)
+		return false
+	}
+
+	return true
+}
+
+// HasSuffix reports a failure if s does not end with suffix.
+func HasSuffix(t testing.TB, fail Reporter, s, suffix string) bool {
+	t.Helper()
+
+	if !strings.HasSuffix(s, suffix) {
+		fail("%q does not have suffix %q", s, suffix)
+		return false
+	}
+
+	return true
+}
+
+// MatchesRegexp reports a failure if s does not match the regular expression pattern,
+// or if pattern cannot be compiled.
+func MatchesRegexp(t testing.TB, fail Reporter, s, pattern string) bool {
+	t.Helper()
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		fail("invalid regexp %q: %v", pattern, err)
+		return false
+	}
+
+	if !re.MatchString(s) {
+		fail("%q does not match regexp %q", s, pattern)
+		return false
+	}
+
+	return true
+}
+
+// EqualFold reports a failure if result and expected are not equal under Unicode case-folding.
+func EqualFold(t testing.TB, fail Reporter, result, expected string) bool {
+	t.Helper()
+
+	if !strings.EqualFold(result, expected) {
+		fail("%q is not equal to %q (ignoring case)", result, expected)
+		return false
+	}
+
+	return true
+}
+
+// EqualLines reports a failure if result and expected are not equal.
+// The failure message shows the first differing line with a marker under the first differing column,
+// followed by a line-level diff.
+func EqualLines(t testing.TB, fail Reporter, result, expected string) bool {
+	t.Helper()
+
+	if result == expected {
+		return true
+	}
+
+	resLines := strings.Split(result, "\n")
+	expLines := strings.Split(expected, "\n")
+
+	line := 0
+	for line < len(resLines) && line < len(expLines) && resLines[line] == expLines[line] {
+		line++
+	}
+
+	resLine, expLine := lineAt(resLines, line), lineAt(expLines, line)
+	col := firstDifference(resLine, expLine)
+
+	fail("strings differ at line %d, column %d:\n  result:   %s\n  expected: %s\n  %s^\n%s",
+		line+1, col+1, resLine, expLine, strings.Repeat(" ", len("expected: ")+col), diffLines(result, expected))
+	return false
+}
+
+// lineAt returns lines[i], or a placeholder if lines has no such line.
+func lineAt(lines []string, i int) string {
+	if i >= len(lines) {
+		return "<no line>"
+	}
+
+	return lines[i]
+}
+
+// firstDifference returns the index of the first rune at which a and b differ.
+func firstDifference(a, b string) int {
+	col := 0
+	for a != "" && b != "" {
+		ra, na := utf8.DecodeRuneInString(a)
+		rb, nb := utf8.DecodeRuneInString(b)
+		if ra != rb {
+			break
+		}
+
+		a, b = a[na:], b[nb:]
+		col++
+	}
+
+	return col
+}
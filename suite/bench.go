@@ -0,0 +1,200 @@
+package suite
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// A benchmarkTimer controls a benchmark's timer. It is implemented by *testing.B,
+// and by testing.TB wrappers (such as those returned by testx.Wrap) which forward to one.
+type benchmarkTimer interface {
+	StartTimer()
+	StopTimer()
+	ResetTimer()
+}
+
+// Warm runs the Setup method of the suite S, which must have been previously registered using Register.
+// It is intended to be called in TestMain before m.Run, so that expensive setups are not ran
+// while benchmarks (or time-sensitive tests) are running:
+//
+//	func TestMain(m *testing.M) {
+//		suite.Register(&DBSuite{})
+//		if err := suite.Warm[*DBSuite](); err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		os.Exit(suite.Run(m))
+//	}
+//
+// Since no test is running, the testing.TB passed to Setup only supports logging, failing, skipping,
+// and the Cleanup and TempDir methods. Per-test hooks are not ran.
+func Warm[S Suite]() error {
+	var s S
+	return defaultRegistry.warm(newSuiteManager(s).Type())
+}
+
+// warm runs the Setup method of the suite registered under key outside of a test.
+func (r *registry) warm(key string) error {
+	if !flag.Parsed() {
+		flag.Parse()
+	}
+
+	m, ok := r.Get(key)
+	if !ok {
+		return fmt.Errorf("suite of type %v has not been registered", key)
+	}
+
+	tb := &warmupTB{name: "suite.Warm[" + key + "]"}
+
+	// Run setup in a separate goroutine so tb.FailNow and tb.SkipNow can exit it.
+	var (
+		err  error
+		done = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		err = m.Setup(tb)
+	}()
+	<-done
+	tb.runCleanups()
+
+	switch {
+	case err != nil:
+		return err
+	case tb.Skipped():
+		return Unavailable(tb.logs())
+	case tb.Failed():
+		return errors.New(tb.logs())
+	default:
+		return nil
+	}
+}
+
+// A warmupTB is the testing.TB passed to Setup methods ran by Warm.
+// Methods which aren't implemented by warmupTB will panic.
+type warmupTB struct {
+	testing.TB
+
+	name string
+
+	mux      sync.Mutex
+	output   []string
+	failed   bool
+	skipped  bool
+	cleanups []func()
+}
+
+func (w *warmupTB) Name() string { return w.name }
+
+func (w *warmupTB) Helper() {}
+
+func (w *warmupTB) Log(args ...any) { w.log(fmt.Sprintln(args...)) }
+
+func (w *warmupTB) Logf(format string, args ...any) { w.log(fmt.Sprintf(format, args...)) }
+
+func (w *warmupTB) Error(args ...any) {
+	w.Log(args...)
+	w.Fail()
+}
+
+func (w *warmupTB) Errorf(format string, args ...any) {
+	w.Logf(format, args...)
+	w.Fail()
+}
+
+func (w *warmupTB) Fatal(args ...any) {
+	w.Log(args...)
+	w.FailNow()
+}
+
+func (w *warmupTB) Fatalf(format string, args ...any) {
+	w.Logf(format, args...)
+	w.FailNow()
+}
+
+func (w *warmupTB) Skip(args ...any) {
+	w.Log(args...)
+	w.SkipNow()
+}
+
+func (w *warmupTB) Skipf(format string, args ...any) {
+	w.Logf(format, args...)
+	w.SkipNow()
+}
+
+func (w *warmupTB) Fail() {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	w.failed = true
+}
+
+func (w *warmupTB) FailNow() {
+	w.Fail()
+	runtime.Goexit()
+}
+
+func (w *warmupTB) SkipNow() {
+	w.mux.Lock()
+	w.skipped = true
+	w.mux.Unlock()
+	runtime.Goexit()
+}
+
+func (w *warmupTB) Failed() bool {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	return w.failed
+}
+
+func (w *warmupTB) Skipped() bool {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	return w.skipped
+}
+
+func (w *warmupTB) Cleanup(fn func()) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	w.cleanups = append(w.cleanups, fn)
+}
+
+func (w *warmupTB) TempDir() string {
+	dir, err := os.MkdirTemp("", "suite-warm")
+	if err != nil {
+		w.Fatalf("TempDir: %s", err.Error())
+	}
+
+	w.Cleanup(func() { os.RemoveAll(dir) })
+	return dir
+}
+
+func (w *warmupTB) log(s string) {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	w.output = append(w.output, strings.TrimSuffix(s, "\n"))
+}
+
+// logs returns everything logged to w.
+func (w *warmupTB) logs() string {
+	w.mux.Lock()
+	defer w.mux.Unlock()
+	return strings.Join(w.output, "\n")
+}
+
+func (w *warmupTB) runCleanups() {
+	w.mux.Lock()
+	cleanups := w.cleanups
+	w.cleanups = nil
+	w.mux.Unlock()
+
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+}
@@ -0,0 +1,236 @@
+package suite
+
+import (
+	"encoding"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+// configTag is the struct tag used to configure suite fields.
+//
+// The tag value is a comma-separated list of options:
+//
+// • env=NAME: populate the field from the environment variable NAME.
+//
+// • flag=NAME: populate the field from the test flag -NAME. The flag is defined when the suite is registered.
+//
+// • default=VALUE: populate the field with VALUE if no other source provides one and the field is the zero value.
+//
+// • required: fail Setup if no source provides a value and the field is the zero value.
+//
+// Sources are checked in order of precedence: flag, env, then the field's existing value, then default.
+// Supported field types are strings, bools, ints, uints, floats, time.Duration, and
+// types implementing encoding.TextUnmarshaler.
+const configTag = "testx"
+
+// A configField describes a suite field populated by the testx struct tag.
+type configField struct {
+	name       string
+	index      int
+	env        string
+	flag       string
+	flagValue  *string
+	def        string
+	hasDefault bool
+	required   bool
+}
+
+// A suiteConfig populates a suite's tagged fields.
+type suiteConfig struct {
+	fs     *flag.FlagSet
+	fields []configField
+}
+
+// parseConfig parses the testx tags on s's exported fields, defining any referenced flags in fs.
+func parseConfig(s Suite, fs *flag.FlagSet) (*suiteConfig, error) {
+	return parseConfigType(reflect.TypeOf(s), fs)
+}
+
+// parseConfigType parses the testx tags on the exported fields of the suite type st.
+// Suite types which are not pointers to structs have no configurable fields.
+func parseConfigType(st reflect.Type, fs *flag.FlagSet) (*suiteConfig, error) {
+	cfg := &suiteConfig{fs: fs}
+	if st.Kind() != reflect.Ptr || st.Elem().Kind() != reflect.Struct {
+		return cfg, nil
+	}
+
+	t := st.Elem()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, ok := sf.Tag.Lookup(configTag)
+		if !ok {
+			continue
+		}
+
+		if !sf.IsExported() {
+			return nil, fmt.Errorf("field %s.%s: %s tag on unexported field", t.Name(), sf.Name, configTag)
+		}
+
+		field := configField{name: sf.Name, index: i}
+		for _, opt := range strings.Split(tag, ",") {
+			key, val, hasVal := strings.Cut(strings.TrimSpace(opt), "=")
+			switch {
+			case key == "env" && hasVal:
+				field.env = val
+			case key == "flag" && hasVal:
+				field.flag = val
+			case key == "default" && hasVal:
+				field.def = val
+				field.hasDefault = true
+			case key == "required" && !hasVal:
+				field.required = true
+			case key == "":
+			default:
+				return nil, fmt.Errorf("field %s.%s: invalid %s tag option %q", t.Name(), sf.Name, configTag, opt)
+			}
+		}
+
+		// Validate the field's type and default value so mistakes are reported when the suite is registered.
+		if field.hasDefault {
+			if err := setField(reflect.New(sf.Type).Elem(), field.def); err != nil {
+				return nil, errors.Wrapf(err, "field %s.%s: invalid default value %q", t.Name(), sf.Name, field.def)
+			}
+		} else if !isSupportedFieldType(sf.Type) {
+			return nil, fmt.Errorf("field %s.%s: unsupported field type %v", t.Name(), sf.Name, sf.Type)
+		}
+
+		if field.flag != "" {
+			usage := fmt.Sprintf("sets %s.%s", t.Name(), sf.Name)
+			if field.env != "" {
+				usage += fmt.Sprintf(" (overrides $%s)", field.env)
+			}
+
+			field.flagValue = fs.String(field.flag, "", usage)
+		}
+
+		cfg.fields = append(cfg.fields, field)
+	}
+
+	return cfg, nil
+}
+
+// Apply populates s's configured fields, returning an error for each field which could not be populated.
+func (c *suiteConfig) Apply(s Suite) error {
+	if len(c.fields) == 0 {
+		return nil
+	}
+
+	setFlags := map[string]bool{}
+	c.fs.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+
+	v := reflect.ValueOf(s).Elem()
+	var errs []error
+	for _, field := range c.fields {
+		fv := v.Field(field.index)
+
+		var (
+			raw    string
+			source string
+		)
+
+		switch {
+		case field.flag != "" && setFlags[field.flag]:
+			raw, source = *field.flagValue, "flag -"+field.flag
+		case field.env != "" && os.Getenv(field.env) != "":
+			raw, source = os.Getenv(field.env), "env "+field.env
+		case !fv.IsZero():
+			continue
+		case field.hasDefault:
+			raw, source = field.def, "default"
+		case field.required:
+			errs = append(errs, fmt.Errorf("field %s: required value not set", field.name))
+			continue
+		default:
+			continue
+		}
+
+		if err := setField(fv, raw); err != nil {
+			errs = append(errs, errors.Wrapf(err, "field %s: invalid value %q from %s", field.name, raw, source))
+		}
+	}
+
+	return multierr.Combine(errs...)
+}
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+func isSupportedFieldType(t reflect.Type) bool {
+	if t == durationType || reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// setField parses raw into v according to v's type.
+func setField(v reflect.Value, raw string) error {
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(raw))
+	}
+
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(int64(d))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(raw, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(raw, 0, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported field type %v", v.Type())
+	}
+
+	return nil
+}
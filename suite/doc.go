@@ -23,12 +23,33 @@
 // T's Setup method will be executed.
 // If T's Setup method returns an error, tb.Fatal will be called.
 // Any subsequent calls to suite.Get[T](tb) will immediately call tb.Fatal with the same error.
+// Suites whose prerequisites are missing (e.g. no Docker daemon) can return an error wrapping suite.ErrUnavailable
+// from Setup, which causes suite.Get to call tb.Skip instead. Suites which embed suite.Integration are skipped
+// automatically when running 'go test -short'.
+//
+// Suites which need to prepare or reset state for each test (e.g. truncating tables) can implement
+// the suite.BeforeTester and suite.AfterTester interfaces.
+// BeforeTest runs the first time a test retrieves the suite, and AfterTest runs via tb.Cleanup once that test finishes.
+// Suites implementing suite.Snapshotter have their state captured after Setup and restored before each test
+// (see the sqlsnapshot package for SQL tables).
+//
+// Parallel tests which must not share state can use suite.RegisterPool and suite.Acquire instead of
+// suite.Register and suite.Get: each test acquires one of several independently set up instances.
+// Suites which are parameterized (e.g. "a product named X") can be registered using suite.RegisterFactory
+// and retrieved using suite.GetWith, which creates and caches one instance per distinct parameter value.
 //
 // The suite.Run function calls suite.Teardown once the tests have finished running.
+// It also calls suite.Teardown if the test binary is interrupted (SIGINT/SIGTERM) or is about to hit its -test.timeout.
 // Any suite whose Setup method was executed will have their Teardown method executed.
 // While suite.Teardown can technically be called at any time, it's recommended to use suite.Run instead
-// of calling suite.Teardown manually. Teardown methods happen on a FILO basis from which they are registered;
-// the suite that should be torn down last should be registered first.
+// of calling suite.Teardown manually. Teardown methods are called in the reverse order the suites' Setup methods
+// completed, so suites are torn down before the suites they retrieved during Setup.
+// Use suite.SetTeardownOrder(suite.RegistrationOrder) to tear suites down on a FILO basis from which they are registered.
+//
+// Suites implementing suite.SharedSuite can be shared between the test processes started by 'go test ./...'
+// by setting the TESTX_SUITE_SHARED_DIR environment variable.
+// To debug the resources suites leave behind, teardowns can be skipped using the -suite.keep flag
+// or TESTX_SUITE_KEEP environment variable (see suite.Run).
 //
 //  package example
 //
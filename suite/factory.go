@@ -0,0 +1,117 @@
+package suite
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// RegisterFactory allows instances of S to be later retrieved using GetWith.
+// An instance is created by calling factory the first time GetWith is called with a distinct params value,
+// e.g. RegisterFactory(func(name string) *ProductSuite { return &ProductSuite{ProductName: name} })
+// allows tests to retrieve a product suite for any product name.
+// Teardowns are ordered in the same way as Register (see TeardownOrder).
+func RegisterFactory[S Suite, P comparable](factory func(P) S) {
+	f, err := newFactory(
+		reflect.TypeOf((*S)(nil)).Elem(),
+		reflect.TypeOf((*P)(nil)).Elem(),
+		func(params any) Suite { return factory(params.(P)) },
+		flag.CommandLine,
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := defaultRegistry.InsertFactory(f.key, f); err != nil {
+		panic(err)
+	}
+}
+
+// GetWith returns the instance of S created for params by the factory registered using RegisterFactory.
+// Each distinct params value has its own instance; calls with equal params return the same instance.
+// Setup, setup failures, and per-test hooks are handled in the same way as Get.
+func GetWith[S Suite, P comparable](tb testing.TB, params P) (s S) {
+	tb.Helper()
+
+	return defaultRegistry.getWith(tb, newSuiteManager(s).Type(), params).(S)
+}
+
+// A factory creates and holds one suite instance per distinct parameter value.
+type factory struct {
+	key        string
+	paramsType reflect.Type
+	create     func(params any) Suite
+	config     *suiteConfig
+
+	mux       sync.Mutex
+	instances map[any]*suiteManager
+	managers  []*suiteManager
+}
+
+func newFactory(suiteType, paramsType reflect.Type, create func(params any) Suite, fs *flag.FlagSet) (*factory, error) {
+	// Instances share a single config so flags are only defined once.
+	cfg, err := parseConfigType(suiteType, fs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &factory{
+		key:        suiteType.String(),
+		paramsType: paramsType,
+		create:     create,
+		config:     cfg,
+		instances:  map[any]*suiteManager{},
+	}, nil
+}
+
+// Get returns the manager of the instance for params, creating it if necessary.
+func (f *factory) Get(params any) *suiteManager {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	if m, ok := f.instances[params]; ok {
+		return m
+	}
+
+	m := newSuiteManager(f.create(params))
+	m.name = fmt.Sprintf("%s(%+v)", f.key, params)
+	m.config = f.config
+
+	f.instances[params] = m
+	f.managers = append(f.managers, m)
+	return m
+}
+
+// Managers returns the factory's instances in the order they should be torn down.
+func (f *factory) Managers() []*suiteManager {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	managers := make([]*suiteManager, 0, len(f.managers))
+	for i := len(f.managers) - 1; i >= 0; i-- {
+		managers = append(managers, f.managers[i])
+	}
+
+	return managers
+}
+
+// getWith returns the instance created for params by the factory registered under key.
+func (r *registry) getWith(tb testing.TB, key string, params any) Suite {
+	tb.Helper()
+
+	f, ok := r.GetFactory(key)
+	if !ok {
+		tb.Fatalf("suite factory of type %v has not been registered", key)
+	}
+
+	if t := reflect.TypeOf(params); t != f.paramsType {
+		tb.Fatalf("suite factory of type %v takes parameters of type %v, not %v", key, f.paramsType, t)
+	}
+
+	r.checkOpen(tb, key)
+	m := f.Get(params)
+	m.Use(tb)
+	return m.suite
+}
@@ -0,0 +1,192 @@
+package suite
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// A DependencyGraph describes the dependencies between suites observed while running tests.
+// Nodes are listed in the order suites are torn down.
+type DependencyGraph struct {
+	Nodes []string `json:"nodes"`
+	Edges []Edge   `json:"edges"`
+}
+
+// An Edge indicates the From suite retrieved the To suite during its Setup method.
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Graph returns the dependency graph of the suites whose Setup methods have been executed.
+// A dependency is recorded when a suite's Setup method calls Get (or Acquire) for another suite.
+func Graph() DependencyGraph {
+	return defaultRegistry.Graph()
+}
+
+// Graph returns the dependency graph of the registry's suites.
+func (r *registry) Graph() DependencyGraph {
+	g := DependencyGraph{
+		Nodes: []string{},
+		Edges: []Edge{},
+	}
+
+	managers := map[*suiteManager]bool{}
+	for _, m := range r.Managers() {
+		if m.needsSetup() {
+			continue
+		}
+
+		managers[m] = true
+		g.Nodes = append(g.Nodes, m.Name())
+	}
+
+	for _, e := range dependencies.Edges() {
+		if managers[e.from] && managers[e.to] {
+			g.Edges = append(g.Edges, Edge{From: e.from.Name(), To: e.to.Name()})
+		}
+	}
+
+	return g
+}
+
+// DependenciesOf returns the names of the suites which node depends on.
+func (g DependencyGraph) DependenciesOf(node string) []string {
+	var deps []string
+	for _, e := range g.Edges {
+		if e.From == node {
+			deps = append(deps, e.To)
+		}
+	}
+
+	return deps
+}
+
+// DOT returns the graph in the Graphviz DOT language.
+func (g DependencyGraph) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph suites {\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&sb, "\t%q;\n", n)
+	}
+
+	for _, e := range g.Edges {
+		fmt.Fprintf(&sb, "\t%q -> %q;\n", e.From, e.To)
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// Mermaid returns the graph as a Mermaid flowchart.
+func (g DependencyGraph) Mermaid() string {
+	ids := map[string]string{}
+	var sb strings.Builder
+	sb.WriteString("flowchart TD\n")
+	for i, n := range g.Nodes {
+		ids[n] = fmt.Sprintf("s%d", i)
+		fmt.Fprintf(&sb, "    %s[\"%s\"]\n", ids[n], strings.ReplaceAll(n, `"`, "#quot;"))
+	}
+
+	for _, e := range g.Edges {
+		fmt.Fprintf(&sb, "    %s --> %s\n", ids[e.From], ids[e.To])
+	}
+
+	return sb.String()
+}
+
+// String returns a human-readable dump of the graph in teardown order.
+// Dependencies which are torn down before a suite that depends on them are flagged.
+func (g DependencyGraph) String() string {
+	position := map[string]int{}
+	for i, n := range g.Nodes {
+		position[n] = i
+	}
+
+	var sb strings.Builder
+	sb.WriteString("suite dependency graph (in teardown order):\n")
+	for i, n := range g.Nodes {
+		fmt.Fprintf(&sb, "  %d. %s\n", i+1, n)
+		for _, dep := range g.DependenciesOf(n) {
+			var warning string
+			if position[dep] < i {
+				warning = " (!) torn down before its dependent"
+			}
+
+			fmt.Fprintf(&sb, "       depends on %s%s\n", dep, warning)
+		}
+	}
+
+	return sb.String()
+}
+
+// dependencies records the edges observed between suite managers.
+var dependencies = newDependencyTracker()
+
+type managerEdge struct {
+	from, to *suiteManager
+}
+
+// A dependencyTracker tracks which suites are running Setup for each test, so calls to Get
+// made within a suite's Setup method can be recorded as dependencies.
+type dependencyTracker struct {
+	mux    sync.Mutex
+	setups map[string][]*suiteManager
+	seen   map[managerEdge]bool
+	edges  []managerEdge
+}
+
+func newDependencyTracker() *dependencyTracker {
+	return &dependencyTracker{
+		setups: map[string][]*suiteManager{},
+		seen:   map[managerEdge]bool{},
+	}
+}
+
+// BeginSetup records that m's Setup method is running for the test named test.
+// The returned function must be called once Setup returns.
+func (d *dependencyTracker) BeginSetup(test string, m *suiteManager) (end func()) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	d.setups[test] = append(d.setups[test], m)
+	return func() {
+		d.mux.Lock()
+		defer d.mux.Unlock()
+
+		stack := d.setups[test]
+		if len(stack) <= 1 {
+			delete(d.setups, test)
+			return
+		}
+
+		d.setups[test] = stack[:len(stack)-1]
+	}
+}
+
+// Observe records a dependency on m if the test named test is running another suite's Setup method.
+func (d *dependencyTracker) Observe(test string, m *suiteManager) {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	stack := d.setups[test]
+	if len(stack) == 0 {
+		return
+	}
+
+	e := managerEdge{from: stack[len(stack)-1], to: m}
+	if e.from == e.to || d.seen[e] {
+		return
+	}
+
+	d.seen[e] = true
+	d.edges = append(d.edges, e)
+}
+
+func (d *dependencyTracker) Edges() []managerEdge {
+	d.mux.Lock()
+	defer d.mux.Unlock()
+
+	return append([]managerEdge{}, d.edges...)
+}
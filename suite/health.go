@@ -0,0 +1,78 @@
+package suite
+
+import (
+	"context"
+	"flag"
+	"testing"
+	"time"
+)
+
+var (
+	healthInterval = flag.Duration("suite.health-interval", 10*time.Second, "minimum time between health checks of a suite")
+	healthTimeout  = flag.Duration("suite.health-timeout", 5*time.Second, "maximum time to wait for a suite's health check")
+)
+
+// A HealthChecker is a Suite which can detect that its resources have been lost,
+// e.g. because a container was killed or a connection was dropped.
+type HealthChecker interface {
+	// Healthy should return an error if the suite can no longer be used.
+	// When that happens, the suite is torn down and its Setup method is ran again.
+	Healthy(ctx context.Context) error
+}
+
+// CheckHealth runs the suite's health check if it implements HealthChecker and
+// it has not been checked within the last -suite.health-interval.
+// Unhealthy suites are torn down and set up again; an error is returned if the new setup fails.
+func (s *suiteManager) CheckHealth(tb testing.TB) error {
+	tb.Helper()
+
+	hc, ok := s.suite.(HealthChecker)
+	if !ok {
+		return nil
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.closed {
+		return errTornDown
+	}
+
+	if s.setupErr != nil || time.Since(s.lastHealthy) < *healthInterval {
+		return s.setupErr
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *healthTimeout)
+	defer cancel()
+
+	err := hc.Healthy(ctx)
+	if err == nil {
+		s.lastHealthy = time.Now()
+		return nil
+	}
+
+	tb.Logf("suite %v is unhealthy, running teardown and setup: %s", s.Name(), err.Error())
+	if err := s.teardownUnhealthy(); err != nil {
+		tb.Logf("teardown failed for unhealthy suite %v: %s", s.Name(), err.Error())
+	}
+
+	s.restarts++
+	s.runSetup(tb)
+	if s.setupErr != nil {
+		return s.setupErr
+	}
+
+	tb.Logf("suite %v is healthy after setup", s.Name())
+	return nil
+}
+
+// teardownUnhealthy tears down an unhealthy suite before it is set up again.
+// Shared suites discard their published state, so the new setup doesn't attach to the same broken resources.
+// The caller must hold s.mux.
+func (s *suiteManager) teardownUnhealthy() error {
+	if s.shared == nil {
+		return s.runTeardown()
+	}
+
+	return callSafely(s.Type(), "Teardown", s.shared.Invalidate)
+}
@@ -0,0 +1,88 @@
+package suite
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// A Describer is a Suite which can describe the resources it created (e.g. connection details).
+// Descriptions are printed for suites which are kept alive by the -suite.keep flag.
+type Describer interface {
+	Describe() string
+}
+
+// keepMode determines when Run skips tearing down suites.
+type keepMode string
+
+const (
+	keepNever  keepMode = "never"
+	keepFailed keepMode = "failed"
+	keepAlways keepMode = "always"
+)
+
+// keepEnvVar can be used instead of the -suite.keep flag, e.g. when running 'go test ./...'.
+const keepEnvVar = "TESTX_SUITE_KEEP"
+
+var keep = keepMode(os.Getenv(keepEnvVar))
+
+func init() {
+	flag.Var(&keep, "suite.keep", fmt.Sprintf("skip suite teardowns to allow debugging: %s, %s, or %s (default from $%s)",
+		keepNever, keepFailed, keepAlways, keepEnvVar))
+}
+
+func (k *keepMode) String() string {
+	return string(*k)
+}
+
+func (k *keepMode) Set(v string) error {
+	switch mode := keepMode(strings.ToLower(v)); mode {
+	case "", keepNever, keepFailed, keepAlways:
+		*k = mode
+		return nil
+	default:
+		return fmt.Errorf("invalid keep mode %q: must be %s, %s, or %s", v, keepNever, keepFailed, keepAlways)
+	}
+}
+
+// A runOutcome describes how a test binary's tests finished.
+type runOutcome int
+
+const (
+	runPassed runOutcome = iota
+	runFailed
+	// runInterrupted means the process received a signal or reached -test.timeout while tests were running.
+	runInterrupted
+)
+
+// Keep returns true if suites should not be torn down after tests finish with the given outcome.
+// Interrupted runs are only kept in the "always" mode, so that cancelling tests (e.g. with Ctrl-C, or a CI
+// job's SIGTERM) doesn't leak the resources created by suites.
+func (k keepMode) Keep(outcome runOutcome) bool {
+	switch k {
+	case keepAlways:
+		return true
+	case keepFailed:
+		return outcome == runFailed
+	default:
+		return false
+	}
+}
+
+// describeKept writes a description of each suite whose Setup method was executed to w.
+func describeKept(w io.Writer, r *registry) {
+	for _, m := range r.Managers() {
+		if m.needsSetup() {
+			continue
+		}
+
+		fmt.Fprintf(w, "--- KEEP: skipped teardown for suite %v\n", m.Name())
+		if d, ok := m.suite.(Describer); ok {
+			for _, line := range strings.Split(d.Describe(), "\n") {
+				fmt.Fprintf(w, "    %s\n", line)
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package suite
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// A PanicError is returned in place of a panic raised by a suite's Setup or Teardown method.
+type PanicError struct {
+	// Suite is the type of the suite which panicked.
+	Suite string
+	// Method is the name of the method which panicked.
+	Method string
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the goroutine which panicked.
+	Stack []byte
+}
+
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("%s panicked in suite %s: %v\n%s", p.Method, p.Suite, p.Value, p.Stack)
+}
+
+// callSafely calls fn, converting a panic into a *PanicError.
+// A panic with a nil value is still converted, since recover returns nil for it.
+func callSafely(suite, method string, fn func() error) (err error) {
+	completed := false
+	defer func() {
+		if !completed {
+			err = &PanicError{
+				Suite:  suite,
+				Method: method,
+				Value:  recover(),
+				Stack:  debug.Stack(),
+			}
+		}
+	}()
+
+	err = fn()
+	completed = true
+	return err
+}
@@ -0,0 +1,162 @@
+package suite
+
+import (
+	"flag"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// A Resetter is a pooled Suite which resets its state before being returned to its pool.
+type Resetter interface {
+	// Reset is called once a test which acquired the suite using Acquire has finished.
+	// If an error is returned, the suite instance is torn down and replaced by a new instance.
+	Reset() error
+}
+
+// RegisterPool allows size independent instances of S, created by factory, to be later retrieved using Acquire.
+// This allows parallel tests to use a suite without sharing its state.
+// Teardowns are ordered in the same way as Register (see TeardownOrder).
+// Panics if size is less than 1.
+func RegisterPool[S Suite](factory func() S, size int) {
+	if size < 1 {
+		panic(fmt.Sprintf("invalid pool size %d: must be at least 1", size))
+	}
+
+	p, err := newPool(func() Suite { return factory() }, size, flag.CommandLine)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := defaultRegistry.InsertPool(p.key, p); err != nil {
+		panic(err)
+	}
+}
+
+// Acquire returns an instance of S from the pool registered using RegisterPool.
+// If all of the pool's instances are in use, Acquire blocks until one is released.
+// The instance is returned to the pool once tb's test has finished, after calling its Reset method
+// if it implements Resetter.
+// Each instance's Setup method is ran the first time it is acquired; setup failures and per-test hooks
+// are handled in the same way as Get.
+func Acquire[S Suite](tb testing.TB) (s S) {
+	tb.Helper()
+
+	return defaultRegistry.acquire(tb, newSuiteManager(s).Type()).(S)
+}
+
+// A pool holds independent instances of a suite type.
+type pool struct {
+	key     string
+	factory func() Suite
+	config  *suiteConfig
+
+	available chan *suiteManager
+
+	mux      sync.Mutex
+	created  int
+	managers []*suiteManager
+}
+
+func newPool(factory func() Suite, size int, fs *flag.FlagSet) (*pool, error) {
+	p := &pool{
+		factory:   factory,
+		available: make(chan *suiteManager, size),
+	}
+
+	first := factory()
+	p.key = newSuiteManager(first).Type()
+
+	// Instances share a single config so flags are only defined once.
+	cfg, err := parseConfig(first, fs)
+	if err != nil {
+		return nil, err
+	}
+	p.config = cfg
+
+	p.available <- p.newManager(first)
+	for i := 1; i < size; i++ {
+		p.available <- p.newManager(factory())
+	}
+
+	return p, nil
+}
+
+func (p *pool) newManager(s Suite) *suiteManager {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	m := newSuiteManager(s)
+	m.name = fmt.Sprintf("%s#%d", p.key, p.created)
+	m.config = p.config
+
+	p.created++
+	p.managers = append(p.managers, m)
+	return m
+}
+
+// Managers returns the pool's instances in the order they should be torn down.
+func (p *pool) Managers() []*suiteManager {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	managers := make([]*suiteManager, 0, len(p.managers))
+	for i := len(p.managers) - 1; i >= 0; i-- {
+		managers = append(managers, p.managers[i])
+	}
+
+	return managers
+}
+
+// Release returns m to the pool after resetting it.
+// If m cannot be reset, it is torn down and replaced with a new instance.
+func (p *pool) Release(tb testing.TB, m *suiteManager) {
+	r, ok := m.suite.(Resetter)
+	if ran, _, err := m.setupStatus(); !ok || !ran || err != nil {
+		p.available <- m
+		return
+	}
+
+	if err := r.Reset(); err != nil {
+		tb.Errorf("reset failed for suite %v, replacing instance: %s", m.Name(), err.Error())
+		if err := p.replace(m); err != nil {
+			tb.Errorf("teardown failed for suite %v: %s", m.Name(), err.Error())
+		}
+
+		return
+	}
+
+	p.available <- m
+}
+
+// replace tears down m and makes a new instance available in its place.
+func (p *pool) replace(m *suiteManager) error {
+	p.mux.Lock()
+	for i, pm := range p.managers {
+		if pm == m {
+			p.managers = append(p.managers[:i], p.managers[i+1:]...)
+			break
+		}
+	}
+	p.mux.Unlock()
+
+	defer func() { p.available <- p.newManager(p.factory()) }()
+	return m.Teardown()
+}
+
+// acquire returns an instance from the pool registered under key, releasing it once tb's test has finished.
+func (r *registry) acquire(tb testing.TB, key string) Suite {
+	tb.Helper()
+
+	p, ok := r.GetPool(key)
+	if !ok {
+		tb.Fatalf("suite pool of type %v has not been registered", key)
+	}
+
+	r.checkOpen(tb, key)
+	m := <-p.available
+	tb.Cleanup(func() { p.Release(tb, m) })
+
+	m.Use(tb)
+	return m.suite
+}
@@ -0,0 +1,113 @@
+package suite
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	reportTable = flag.Bool("suite.report", true, "print a table of suite setup/teardown timings to stderr (disable with -suite.report=false)")
+	reportJSON  = flag.String("suite.report-json", "", "write the suite lifecycle report as JSON to the given file path")
+)
+
+// A SuiteReport describes the lifecycle of a suite whose Setup method was executed.
+// Restarts counts the number of times the suite was torn down and set up again after failing a health check.
+// Durations are encoded as nanoseconds in JSON.
+type SuiteReport struct {
+	Suite            string        `json:"suite"`
+	SetupDuration    time.Duration `json:"setup_duration"`
+	SetupError       string        `json:"setup_error,omitempty"`
+	TeardownDuration time.Duration `json:"teardown_duration"`
+	TeardownError    string        `json:"teardown_error,omitempty"`
+	Restarts         int           `json:"restarts"`
+	Tests            []string      `json:"tests"`
+}
+
+// Reports returns a lifecycle report for each registered suite whose Setup method was executed,
+// in the order the suites were registered.
+func Reports() []SuiteReport {
+	return defaultRegistry.Reports()
+}
+
+// Reports returns lifecycle reports for the registry's suites which ran their Setup methods.
+func (r *registry) Reports() []SuiteReport {
+	reports := []SuiteReport{}
+	managers := r.registeredManagers()
+	for i := len(managers) - 1; i >= 0; i-- {
+		m := managers[i]
+		if m.needsSetup() {
+			continue
+		}
+
+		reports = append(reports, m.Report())
+	}
+
+	return reports
+}
+
+// writeReports emits reports according to the -suite.report and -suite.report-json flags,
+// printing the table to w.
+func writeReports(w io.Writer, reports []SuiteReport) error {
+	if len(reports) > 0 && *reportTable {
+		writeReportTable(w, reports)
+	}
+
+	if path := *reportJSON; path != "" {
+		data, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			return err
+		}
+
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return errors.Wrap(err, "failed to write suite report")
+		}
+	}
+
+	return nil
+}
+
+func writeReportTable(w io.Writer, reports []SuiteReport) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SUITE\tSETUP\tTEARDOWN\tRESTARTS\tTESTS\tERROR")
+	for _, r := range reports {
+		var errMsg string
+		switch {
+		case r.SetupError != "":
+			errMsg = "setup: " + r.SetupError
+		case r.TeardownError != "":
+			errMsg = "teardown: " + r.TeardownError
+		}
+
+		// Only show the first line of multi-line errors, e.g. a *PanicError's stack trace.
+		if i := strings.IndexByte(errMsg, '\n'); i >= 0 {
+			errMsg = errMsg[:i]
+		}
+
+		fmt.Fprintf(tw, "%s\t%v\t%v\t%d\t%d\t%s\n",
+			r.Suite,
+			r.SetupDuration.Round(time.Millisecond),
+			r.TeardownDuration.Round(time.Millisecond),
+			r.Restarts,
+			len(r.Tests),
+			errMsg,
+		)
+	}
+
+	tw.Flush()
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,255 @@
+package suite
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"go.uber.org/multierr"
+)
+
+var teardownTimeout = flag.Duration(
+	"suite.teardown-timeout",
+	30*time.Second,
+	"maximum time to wait for suite teardowns after an interrupt or test timeout",
+)
+
+// Run is a helper method which calls m.Run and the Teardown function,
+// returning the exit code from m.Run or 1 if an error occured during Teardown.
+//
+// If the test binary receives SIGINT or SIGTERM, Run executes the Teardown function
+// (waiting at most -suite.teardown-timeout) before the process exits with code 128 + the signal number.
+// A second signal terminates the process immediately, without waiting for teardowns.
+// If -test.timeout is set, Run starts the Teardown function a grace period before the deadline and
+// then fails the binary as the testing package would, so the binary fails up to the grace period earlier
+// than -test.timeout. The grace period is -suite.teardown-timeout, limited to a tenth of -test.timeout.
+//
+// Teardowns can be skipped to allow debugging the resources suites leave behind by using
+// the -suite.keep flag (or the TESTX_SUITE_KEEP environment variable):
+// "failed" skips teardowns if the tests failed, and "always" skips them unconditionally.
+// Interrupted runs (including those which reach -test.timeout) are torn down unless the mode is "always".
+// Each kept suite is printed to stderr, along with its description if it implements Describer.
+//
+// Once teardowns have completed, a table of suite setup/teardown timings is printed to stderr
+// unless -suite.report=false is set. The -suite.report-json flag writes the
+// same report (see Reports) as JSON to the given file path.
+// If a teardown fails, the suite dependency graph (see Graph) is printed to stderr.
+func Run(m *testing.M) int {
+	if !flag.Parsed() {
+		flag.Parse()
+	}
+
+	// The flag package validates -suite.keep, but not values from the environment.
+	if err := keep.Set(keep.String()); err != nil {
+		fmt.Fprintf(os.Stderr, "--- ERROR: %s\n", err.Error())
+		return 1
+	}
+
+	var (
+		once        sync.Once
+		teardownErr error
+	)
+
+	teardown := func(outcome runOutcome) error {
+		once.Do(func() {
+			if keep.Keep(outcome) {
+				describeKept(os.Stderr, defaultRegistry)
+			} else {
+				teardownErr = Teardown()
+			}
+
+			if err := writeReports(os.Stderr, Reports()); err != nil {
+				teardownErr = multierr.Append(teardownErr, err)
+			}
+		})
+
+		return teardownErr
+	}
+
+	stop := handleInterrupts(func() error { return teardown(runInterrupted) })
+	code := m.Run()
+	stop()
+
+	outcome := runPassed
+	if code != 0 {
+		outcome = runFailed
+	}
+
+	if err := teardown(outcome); err != nil {
+		printErrors(err)
+		fmt.Fprint(os.Stderr, Graph())
+		return 1
+	}
+
+	return code
+}
+
+// handleInterrupts runs teardown if the process receives SIGINT or SIGTERM,
+// or if the test timeout is about to be reached.
+// The returned function stops handling interrupts.
+func handleInterrupts(teardown func() error) func() {
+	sigs := make(chan os.Signal, 1)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+
+	h := &interruptHandler{
+		signals:         sigs,
+		stopSignals:     func() { signal.Stop(sigs) },
+		testTimeout:     testTimeout(),
+		teardownTimeout: *teardownTimeout,
+		teardown:        teardown,
+		exit:            os.Exit,
+		timedOut: func(d time.Duration) {
+			// Mirror the testing package's behavior when the test timeout is reached.
+			debug.SetTraceback("all")
+			panic(fmt.Sprintf("test timed out after %v", d))
+		},
+	}
+
+	stop := h.Start()
+	return func() {
+		signal.Stop(sigs)
+		stop()
+	}
+}
+
+// An interruptHandler runs teardowns when a signal is received or the test timeout is about to be reached.
+type interruptHandler struct {
+	signals <-chan os.Signal
+	// stopSignals is called once a signal is received, so that another signal
+	// (e.g. a second Ctrl-C while teardowns hang) terminates the process immediately.
+	stopSignals func()
+	// testTimeout is the value of -test.timeout; 0 disables the timeout.
+	testTimeout     time.Duration
+	teardownTimeout time.Duration
+	teardown        func() error
+
+	// exit is called with the process's exit code once teardowns have run after a signal.
+	exit func(code int)
+	// timedOut is called with the test timeout once teardowns have run before it is reached.
+	timedOut func(testTimeout time.Duration)
+}
+
+// Grace returns how long before the test timeout teardowns are started.
+// It is limited to a tenth of the test timeout, so that short timeouts are not consumed by the grace period.
+func (h *interruptHandler) Grace() time.Duration {
+	grace := h.teardownTimeout
+	if limit := h.testTimeout / 10; grace > limit {
+		grace = limit
+	}
+
+	return grace
+}
+
+// Start handles interrupts until the returned function is called.
+func (h *interruptHandler) Start() (stop func()) {
+	var (
+		timer   *time.Timer
+		timeout <-chan time.Time
+	)
+
+	grace := h.Grace()
+	if h.testTimeout > 0 {
+		// Leave enough room before the testing package panics for teardowns to complete.
+		timer = time.NewTimer(h.testTimeout - grace)
+		timeout = timer.C
+	}
+
+	done := make(chan struct{})
+	stopped := func() bool {
+		select {
+		case <-done:
+			return true
+		default:
+			return false
+		}
+	}
+
+	go func() {
+		select {
+		case sig := <-h.signals:
+			// select picks randomly between ready cases, so a signal may arrive alongside done.
+			if stopped() {
+				return
+			}
+
+			h.stopSignals()
+			fmt.Fprintf(os.Stderr, "--- INTERRUPT: received %v, running suite teardowns (interrupt again to exit immediately)\n", sig)
+			teardownWithin(h.teardownTimeout, h.teardown)
+			h.exit(signalExitCode(sig))
+		case <-timeout:
+			if stopped() {
+				return
+			}
+
+			fmt.Fprintf(os.Stderr, "--- TIMEOUT: test timeout of %v is about to be reached, running suite teardowns\n", h.testTimeout)
+			// Teardowns must complete before the testing package's own alarm fires.
+			teardownWithin(grace, h.teardown)
+			h.timedOut(h.testTimeout)
+		case <-done:
+		}
+	}()
+
+	return func() {
+		if timer != nil {
+			timer.Stop()
+		}
+
+		close(done)
+	}
+}
+
+// teardownWithin runs teardown, printing any errors to stderr.
+// If teardown does not complete within d, teardownWithin returns false without waiting for it.
+func teardownWithin(d time.Duration, teardown func() error) bool {
+	errc := make(chan error, 1)
+	go func() { errc <- teardown() }()
+
+	select {
+	case err := <-errc:
+		if err != nil {
+			printErrors(err)
+		}
+
+		return true
+	case <-time.After(d):
+		fmt.Fprintf(os.Stderr, "--- ERROR: suite teardowns did not complete within %v\n", d)
+		return false
+	}
+}
+
+// testTimeout returns the value of the -test.timeout flag, or 0 if it is not set.
+func testTimeout() time.Duration {
+	f := flag.Lookup("test.timeout")
+	if f == nil {
+		return 0
+	}
+
+	getter, ok := f.Value.(flag.Getter)
+	if !ok {
+		return 0
+	}
+
+	d, _ := getter.Get().(time.Duration)
+	return d
+}
+
+// signalExitCode returns the conventional shell exit code for a process terminated by sig.
+func signalExitCode(sig os.Signal) int {
+	if s, ok := sig.(syscall.Signal); ok {
+		return 128 + int(s)
+	}
+
+	return 1
+}
+
+func printErrors(err error) {
+	for _, err := range multierr.Errors(err) {
+		fmt.Fprintln(os.Stderr, err.Error())
+	}
+}
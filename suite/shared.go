@@ -0,0 +1,272 @@
+package suite
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// A SharedSuite is a Suite which can be shared between test processes.
+//
+// Go runs each package's tests in a separate process, so by default each package which
+// registers a suite will run its own Setup. When the -suite.shared-dir flag (or the
+// TESTX_SUITE_SHARED_DIR environment variable) is set, the first process to retrieve a
+// SharedSuite runs its Setup method and publishes the suite's State to a file in that directory.
+// Other processes call Attach with the published state instead of running Setup.
+// The last process using the suite runs its Teardown method; other processes call Detach.
+// That process may have only called Attach, so State must include everything Teardown needs to
+// destroy the shared resources (e.g. container IDs), and Attach must restore it.
+// If a SharedSuite which implements HealthChecker is unhealthy, the process which detects it runs
+// the suite's Teardown method and discards the published state, so the suite is set up again instead
+// of being re-attached; other processes attach to the new state when their own health checks fail.
+//
+// Processes coordinate using file locks, which are only supported on unix-like systems.
+// Suites are shared by type, so the suite type should be defined in a (non-test) package
+// imported by each test package.
+type SharedSuite interface {
+	Suite
+
+	// State returns the data other processes need to attach to the suite, e.g. JSON connection info.
+	// It is called after Setup succeeds.
+	State() ([]byte, error)
+
+	// Attach configures the suite from the state published by the process which ran Setup.
+	// It is called instead of Setup, and Teardown may be called afterwards if this process is the last one using the suite.
+	Attach(tb testing.TB, state []byte) error
+
+	// Detach releases resources local to the process (e.g. connections) without destroying shared resources.
+	// It is called instead of Teardown when other processes are still using the suite.
+	Detach() error
+}
+
+// sharedDirEnvVar can be used instead of the -suite.shared-dir flag, which is preferable when running 'go test ./...'.
+const sharedDirEnvVar = "TESTX_SUITE_SHARED_DIR"
+
+var sharedDir = flag.String(
+	"suite.shared-dir",
+	os.Getenv(sharedDirEnvVar),
+	"directory used to share suites between test processes (default from $"+sharedDirEnvVar+")",
+)
+
+// sharedState is the content of a shared suite's state file.
+type sharedState struct {
+	// Processes holds the pid of each process using the suite.
+	Processes []int  `json:"processes"`
+	State     []byte `json:"state"`
+}
+
+// A sharedSuite coordinates the lifecycle of a SharedSuite between processes.
+type sharedSuite struct {
+	suite     SharedSuite
+	lockPath  string
+	statePath string
+
+	attached bool
+	joined   bool
+	// state is the published state the suite was set up with or attached to.
+	state []byte
+}
+
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func newSharedSuite(s SharedSuite, dir string) *sharedSuite {
+	t := reflect.TypeOf(s)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	name := unsafeFilenameChars.ReplaceAllString(t.PkgPath()+"."+t.Name(), "_")
+	return &sharedSuite{
+		suite:     s,
+		lockPath:  filepath.Join(dir, name+".lock"),
+		statePath: filepath.Join(dir, name+".json"),
+	}
+}
+
+// Setup attaches to the suite if another process has published its state.
+// Otherwise, it runs the suite's Setup method and publishes its state.
+// State left by processes which are no longer running is logged before it is replaced.
+func (s *sharedSuite) Setup(tb testing.TB) error {
+	if err := os.MkdirAll(filepath.Dir(s.lockPath), 0o755); err != nil {
+		return errors.Wrap(err, "failed to create shared suite directory")
+	}
+
+	unlock, err := lockFile(s.lockPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock shared suite")
+	}
+	defer unlock()
+
+	st, err := s.readState()
+	if err != nil {
+		return err
+	}
+
+	if st != nil && len(st.Processes) == 0 {
+		// Processes remove themselves from the state file on teardown, so they must have exited without tearing down.
+		tb.Logf("discarding stale shared suite state in %s: the processes using it exited without tearing it down, "+
+			"so its resources may have leaked: %s", s.statePath, st.State)
+	}
+
+	if st != nil && len(st.Processes) > 0 {
+		s.attached = true
+		if err := s.suite.Attach(tb, st.State); err != nil {
+			return errors.Wrap(err, "failed to attach to shared suite")
+		}
+
+		st.Processes = append(st.Processes, os.Getpid())
+		if err := s.writeState(st); err != nil {
+			return err
+		}
+
+		s.joined = true
+		s.state = st.State
+		return nil
+	}
+
+	if err := s.suite.Setup(tb); err != nil {
+		return err
+	}
+
+	state, err := s.suite.State()
+	if err != nil {
+		return errors.Wrap(err, "failed to get shared suite state")
+	}
+
+	if err := s.writeState(&sharedState{Processes: []int{os.Getpid()}, State: state}); err != nil {
+		return err
+	}
+
+	s.joined = true
+	s.state = state
+	return nil
+}
+
+// Teardown runs the suite's Teardown method if this is the last process using the suite.
+// Otherwise, it runs the suite's Detach method.
+func (s *sharedSuite) Teardown() error {
+	if !s.joined {
+		if s.attached {
+			return s.suite.Detach()
+		}
+
+		return s.suite.Teardown()
+	}
+
+	unlock, err := lockFile(s.lockPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock shared suite")
+	}
+	defer unlock()
+
+	st, err := s.readState()
+	if err != nil {
+		return err
+	}
+
+	if st != nil {
+		st.Processes = removePid(st.Processes, os.Getpid())
+	}
+
+	if st != nil && len(st.Processes) > 0 {
+		if err := s.writeState(st); err != nil {
+			return err
+		}
+
+		return s.suite.Detach()
+	}
+
+	if err := os.Remove(s.statePath); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to remove shared suite state")
+	}
+
+	return s.suite.Teardown()
+}
+
+// Invalidate discards the suite's published state and runs the suite's Teardown method,
+// so the next process to set up the suite runs its Setup method instead of attaching to broken resources.
+// If another process has already published new state, Invalidate only runs the suite's Detach method.
+func (s *sharedSuite) Invalidate() error {
+	if !s.joined {
+		return s.Teardown()
+	}
+
+	unlock, err := lockFile(s.lockPath)
+	if err != nil {
+		return errors.Wrap(err, "failed to lock shared suite")
+	}
+	defer unlock()
+
+	st, err := s.readState()
+	if err != nil {
+		return err
+	}
+
+	if st == nil || !bytes.Equal(st.State, s.state) {
+		return s.suite.Detach()
+	}
+
+	if err := os.Remove(s.statePath); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to remove shared suite state")
+	}
+
+	return s.suite.Teardown()
+}
+
+// readState reads the suite's state file, pruning processes which are no longer running.
+// A nil state is returned if the file does not exist.
+func (s *sharedSuite) readState() (*sharedState, error) {
+	data, err := os.ReadFile(s.statePath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read shared suite state")
+	}
+
+	var st sharedState
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, errors.Wrap(err, "failed to parse shared suite state")
+	}
+
+	alive := st.Processes[:0]
+	for _, pid := range st.Processes {
+		if processAlive(pid) {
+			alive = append(alive, pid)
+		}
+	}
+	st.Processes = alive
+
+	return &st, nil
+}
+
+func (s *sharedSuite) writeState(st *sharedState) error {
+	data, err := json.Marshal(st)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode shared suite state")
+	}
+
+	if err := os.WriteFile(s.statePath, data, 0o644); err != nil {
+		return errors.Wrap(err, "failed to write shared suite state")
+	}
+
+	return nil
+}
+
+// removePid removes a single occurrence of pid from pids.
+func removePid(pids []int, pid int) []int {
+	for i, p := range pids {
+		if p == pid {
+			return append(pids[:i], pids[i+1:]...)
+		}
+	}
+
+	return pids
+}
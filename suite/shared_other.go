@@ -0,0 +1,16 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+
+package suite
+
+import (
+	"fmt"
+	"runtime"
+)
+
+func lockFile(path string) (unlock func(), err error) {
+	return nil, fmt.Errorf("shared suites are not supported on %s", runtime.GOOS)
+}
+
+func processAlive(pid int) bool {
+	return false
+}
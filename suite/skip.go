@@ -0,0 +1,40 @@
+package suite
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// ErrUnavailable indicates a suite's prerequisites (e.g. a Docker daemon or an environment variable) are missing.
+// If a suite's Setup method returns an error wrapping ErrUnavailable, Get calls tb.Skip instead of tb.Fatal.
+var ErrUnavailable = errors.New("suite unavailable")
+
+// Unavailable returns an error wrapping ErrUnavailable which describes why the suite is unavailable.
+func Unavailable(reason string) error {
+	return errors.Wrap(ErrUnavailable, reason)
+}
+
+// Unavailablef is the same as Unavailable, but formats the reason using fmt.Sprintf.
+func Unavailablef(format string, args ...any) error {
+	return Unavailable(fmt.Sprintf(format, args...))
+}
+
+// An IntegrationSuite is a Suite which depends on external resources.
+// When tests are run with the -short flag, Get skips tests which retrieve an
+// integration suite without running its Setup method.
+type IntegrationSuite interface {
+	IsIntegration() bool
+}
+
+// Integration is a placeholder type which can be embedded into suites
+// to mark them as an IntegrationSuite.
+type Integration struct{}
+
+// IsIntegration returns true.
+func (Integration) IsIntegration() bool { return true }
+
+func isIntegration(s Suite) bool {
+	i, ok := s.(IntegrationSuite)
+	return ok && i.IsIntegration()
+}
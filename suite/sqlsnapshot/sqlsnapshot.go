@@ -0,0 +1,162 @@
+// Package sqlsnapshot snapshots and restores the rows of SQL tables.
+// It is intended to help suites implement the suite.Snapshotter interface:
+//
+//	func (d *DBSuite) Snapshot() (any, error) {
+//		return sqlsnapshot.Take(context.Background(), d.DB, sqlsnapshot.Options{
+//			Tables: []string{"users", "products"},
+//		})
+//	}
+//
+//	func (d *DBSuite) Restore(snapshot any) error {
+//		return snapshot.(*sqlsnapshot.Snapshot).Restore(context.Background(), d.DB)
+//	}
+//
+// Snapshots hold every row of the selected tables in memory, so they are best suited to small fixture tables.
+package sqlsnapshot
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+// Options configure which tables are snapshotted and how they are restored.
+type Options struct {
+	// Tables lists the tables to snapshot.
+	// Tables are restored in this order, and cleared in the reverse order,
+	// so parent tables should be listed before the tables which reference them.
+	Tables []string
+
+	// Placeholder returns the query placeholder for the n-th (1-indexed) argument.
+	// Defaults to QuestionPlaceholder.
+	Placeholder func(n int) string
+}
+
+// QuestionPlaceholder returns "?", the placeholder used by e.g. MySQL and SQLite.
+func QuestionPlaceholder(n int) string { return "?" }
+
+// DollarPlaceholder returns "$n", the placeholder used by e.g. PostgreSQL.
+func DollarPlaceholder(n int) string { return fmt.Sprintf("$%d", n) }
+
+// A Snapshot holds the rows of a set of tables.
+type Snapshot struct {
+	placeholder func(n int) string
+	tables      []table
+}
+
+type table struct {
+	name    string
+	columns []string
+	rows    [][]any
+}
+
+// Take reads every row of the tables specified by opts.
+func Take(ctx context.Context, db *sql.DB, opts Options) (*Snapshot, error) {
+	s := &Snapshot{placeholder: opts.Placeholder}
+	if s.placeholder == nil {
+		s.placeholder = QuestionPlaceholder
+	}
+
+	for _, name := range opts.Tables {
+		t, err := readTable(ctx, db, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to snapshot table %s", name)
+		}
+
+		s.tables = append(s.tables, t)
+	}
+
+	return s, nil
+}
+
+func readTable(ctx context.Context, db *sql.DB, name string) (t table, err error) {
+	rows, err := db.QueryContext(ctx, "SELECT * FROM "+name)
+	if err != nil {
+		return t, err
+	}
+	defer func() { err = multierr.Append(err, rows.Close()) }()
+
+	t.name = name
+	if t.columns, err = rows.Columns(); err != nil {
+		return t, err
+	}
+
+	for rows.Next() {
+		values := make([]any, len(t.columns))
+		dest := make([]any, len(t.columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return t, err
+		}
+
+		t.rows = append(t.rows, values)
+	}
+
+	return t, rows.Err()
+}
+
+// Restore replaces the contents of the snapshotted tables with the snapshotted rows.
+// All changes are made within a single transaction.
+func (s *Snapshot) Restore(ctx context.Context, db *sql.DB) (err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			err = multierr.Append(err, tx.Rollback())
+		}
+	}()
+
+	for i := len(s.tables) - 1; i >= 0; i-- {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+s.tables[i].name); err != nil {
+			return errors.Wrapf(err, "failed to clear table %s", s.tables[i].name)
+		}
+	}
+
+	for _, t := range s.tables {
+		if err := s.insertRows(ctx, tx, t); err != nil {
+			return errors.Wrapf(err, "failed to restore table %s", t.name)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *Snapshot) insertRows(ctx context.Context, tx *sql.Tx, t table) error {
+	if len(t.rows) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(t.columns))
+	for i := range placeholders {
+		placeholders[i] = s.placeholder(i + 1)
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		t.name,
+		strings.Join(t.columns, ", "),
+		strings.Join(placeholders, ", "),
+	)
+
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, row := range t.rows {
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
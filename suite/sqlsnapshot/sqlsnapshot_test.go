@@ -0,0 +1,238 @@
+package sqlsnapshot
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/zpatrick/testx/assert"
+)
+
+// A fakeDB is a database/sql driver which records the statements executed against it.
+// Queries of the form "SELECT * FROM <table>" return the table's rows; other statements have no effect.
+type fakeDB struct {
+	tables map[string]fakeTable
+	// failOn causes statements starting with it to fail.
+	failOn string
+
+	mux sync.Mutex
+	log []string
+}
+
+type fakeTable struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{tables: map[string]fakeTable{
+		"users": {
+			columns: []string{"id", "name"},
+			rows:    [][]driver.Value{{int64(1), "alice"}, {int64(2), "bob"}},
+		},
+		"products": {
+			columns: []string{"id", "user_id"},
+			rows:    [][]driver.Value{{int64(10), int64(1)}},
+		},
+	}}
+}
+
+// Open returns a *sql.DB which uses f.
+func (f *fakeDB) Open(t *testing.T) *sql.DB {
+	db := sql.OpenDB(f)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// Statements returns the recorded statements and clears the log.
+func (f *fakeDB) Statements() []string {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	log := f.log
+	f.log = nil
+	return log
+}
+
+func (f *fakeDB) record(query string, args []driver.Value) error {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	if len(args) > 0 {
+		query += fmt.Sprintf(" %v", args)
+	}
+	f.log = append(f.log, query)
+
+	if f.failOn != "" && strings.HasPrefix(query, f.failOn) {
+		return errors.New("statement failed")
+	}
+
+	return nil
+}
+
+func (f *fakeDB) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: f}, nil }
+
+func (f *fakeDB) Driver() driver.Driver { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return nil, errors.New("use sql.OpenDB") }
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{db: c.db, query: query}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return fakeTx{db: c.db}, c.db.record("BEGIN", nil)
+}
+
+type fakeTx struct {
+	db *fakeDB
+}
+
+func (tx fakeTx) Commit() error { return tx.db.record("COMMIT", nil) }
+
+func (tx fakeTx) Rollback() error { return tx.db.record("ROLLBACK", nil) }
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s *fakeStmt) Close() error { return nil }
+
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if err := s.db.record(s.query, args); err != nil {
+		return nil, err
+	}
+
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if err := s.db.record(s.query, args); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimPrefix(s.query, "SELECT * FROM ")
+	t, ok := s.db.tables[name]
+	if !ok {
+		return nil, fmt.Errorf("no such table: %s", name)
+	}
+
+	return &fakeRows{table: t}, nil
+}
+
+type fakeRows struct {
+	table fakeTable
+	next  int
+}
+
+func (r *fakeRows) Columns() []string { return r.table.columns }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next == len(r.table.rows) {
+		return io.EOF
+	}
+
+	copy(dest, r.table.rows[r.next])
+	r.next++
+	return nil
+}
+
+func TestTake(t *testing.T) {
+	f := newFakeDB()
+	s, err := Take(context.Background(), f.Open(t), Options{Tables: []string{"users", "products"}})
+	assert.NilError(t, err)
+	assert.EqualSlices(t, f.Statements(), []string{"SELECT * FROM users", "SELECT * FROM products"})
+
+	assert.Equal(t, len(s.tables), 2)
+	assert.EqualSlices(t, s.tables[0].columns, []string{"id", "name"})
+	assert.DeepEqual(t, s.tables[0].rows, [][]any{{int64(1), "alice"}, {int64(2), "bob"}})
+}
+
+func TestTake_error(t *testing.T) {
+	f := newFakeDB()
+	_, err := Take(context.Background(), f.Open(t), Options{Tables: []string{"users", "orders"}})
+	assert.Error(t, err)
+	assert.StringContains(t, err.Error(), "failed to snapshot table orders")
+}
+
+func TestSnapshot_Restore(t *testing.T) {
+	testCases := []struct {
+		Name        string
+		Placeholder func(n int) string
+		Values      string
+	}{
+		{"default placeholder", nil, "(?, ?)"},
+		{"dollar placeholder", DollarPlaceholder, "($1, $2)"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			f := newFakeDB()
+			db := f.Open(t)
+			s, err := Take(context.Background(), db, Options{
+				Tables:      []string{"users", "products"},
+				Placeholder: tc.Placeholder,
+			})
+			assert.NilError(t, err)
+			f.Statements()
+
+			// Children are cleared before their parents, and parents are restored before their children.
+			assert.NilError(t, s.Restore(context.Background(), db))
+			assert.EqualSlices(t, f.Statements(), []string{
+				"BEGIN",
+				"DELETE FROM products",
+				"DELETE FROM users",
+				"INSERT INTO users (id, name) VALUES " + tc.Values + " [1 alice]",
+				"INSERT INTO users (id, name) VALUES " + tc.Values + " [2 bob]",
+				"INSERT INTO products (id, user_id) VALUES " + tc.Values + " [10 1]",
+				"COMMIT",
+			})
+		})
+	}
+}
+
+func TestSnapshot_Restore_rollback(t *testing.T) {
+	f := newFakeDB()
+	db := f.Open(t)
+	s, err := Take(context.Background(), db, Options{Tables: []string{"users", "products"}})
+	assert.NilError(t, err)
+	f.Statements()
+
+	f.failOn = "INSERT INTO products"
+	err = s.Restore(context.Background(), db)
+	assert.Error(t, err)
+	assert.StringContains(t, err.Error(), "failed to restore table products")
+	assert.EqualSlices(t, f.Statements(), []string{
+		"BEGIN",
+		"DELETE FROM products",
+		"DELETE FROM users",
+		"INSERT INTO users (id, name) VALUES (?, ?) [1 alice]",
+		"INSERT INTO users (id, name) VALUES (?, ?) [2 bob]",
+		"INSERT INTO products (id, user_id) VALUES (?, ?) [10 1]",
+		"ROLLBACK",
+	})
+}
+
+func TestDollarPlaceholder(t *testing.T) {
+	assert.Equal(t, DollarPlaceholder(3), "$3")
+	assert.Equal(t, QuestionPlaceholder(3), "?")
+}
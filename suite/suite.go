@@ -1,11 +1,14 @@
 package suite
 
 import (
+	"flag"
 	"fmt"
-	"os"
 	"reflect"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
@@ -26,6 +29,35 @@ type Suite interface {
 	Teardown() error
 }
 
+// A BeforeTester is a Suite which prepares state before each test which uses it.
+type BeforeTester interface {
+	// BeforeTest is called when a test first retrieves the suite using Get,
+	// after the suite's Setup method has succeeded.
+	// If an error is returned, tb.Fatal will be called.
+	BeforeTest(tb testing.TB) error
+}
+
+// A Snapshotter is a Suite whose state can be captured after Setup and restored before each test.
+// This is typically faster than resetting state by running Setup again.
+type Snapshotter interface {
+	// Snapshot is called after the suite's Setup method succeeds.
+	// If an error is returned, it is treated as a setup error.
+	Snapshot() (any, error)
+
+	// Restore is called with the value returned by Snapshot when a test retrieves the suite using Get,
+	// unless no other test has retrieved the suite since Snapshot was called.
+	// Restore is called before BeforeTest; if an error is returned, tb.Fatal will be called.
+	Restore(snapshot any) error
+}
+
+// An AfterTester is a Suite which cleans up state after each test which uses it.
+type AfterTester interface {
+	// AfterTest is called once a test which retrieved the suite using Get has finished.
+	// It is registered using tb.Cleanup, so it will run even if the test fails.
+	// If an error is returned, tb.Error will be called.
+	AfterTest(tb testing.TB) error
+}
+
 // Base is a placeholder type which can be embedded into types
 // which don't need to implement the Setup or Teardown methods.
 type Base struct{}
@@ -38,11 +70,28 @@ func (Base) Teardown() error { return nil }
 
 // Register allows a suite of s's concrete type to be later retrieved using Get.
 // Only one instance of type s's concrete type should be registered.
-// The order in which suites are registered determines the order teardown methods are called:
-// Teardowns happen on a FILO (first in, last out) basis.
+// Suites are torn down in the reverse order their Setup methods completed,
+// unless SetTeardownOrder(RegistrationOrder) is used (see TeardownOrder).
+//
+// Exported fields of s tagged with `testx:"..."` are populated from environment variables,
+// test flags, and default values before s's Setup method is ran, e.g.:
+//
+//	type DBSuite struct {
+//		Port     int    `testx:"env=APP_DB_PORT,flag=db.port,default=3306"`
+//		Password string `testx:"env=APP_DB_PASSWORD,required"`
+//	}
+//
+// Flags are checked first, then environment variables, then the field's existing value, then the default.
+// Register panics if a tag is malformed; errors populating fields are returned as the suite's setup error.
 func Register(s Suite) {
 	m := newSuiteManager(s)
 
+	cfg, err := parseConfig(s, flag.CommandLine)
+	if err != nil {
+		panic(err)
+	}
+	m.config = cfg
+
 	if err := defaultRegistry.Insert(m.Type(), m); err != nil {
 		panic(err)
 	}
@@ -50,119 +99,500 @@ func Register(s Suite) {
 
 // Get returns the instance of S which must have been previously registered using Register.
 // If this is the first time Get is called for type S, the suite's Setup method will be ran.
-// If the suite's Setup method fails, tb.Fatal will be called.
+// If the suite's Setup method fails (or panics), tb.Fatal will be called, unless the error wraps ErrUnavailable,
+// in which case tb.Skip will be called instead.
+// If the suite is an IntegrationSuite and tests are run with -short, tb.Skip will be called without running Setup.
+// If tb is a *testing.B (or a wrapper with StopTimer, ResetTimer, and StartTimer methods, such as testx.Wrap),
+// the benchmark timer is stopped while Setup runs and reset afterwards (see Warm to run setups before benchmarks start).
+// If the suite implements BeforeTester or AfterTester, the hooks are ran once per test;
+// subsequent calls to Get from the same test will not run them again.
 func Get[S Suite](tb testing.TB) (s S) {
-	sType := newSuiteManager(s).Type()
-	m, ok := defaultRegistry.Get(sType)
-	if !ok {
-		tb.Fatalf("suite of type %v has not been registered", sType)
-	}
+	tb.Helper()
 
-	if err := m.Setup(tb); err != nil {
-		tb.Fatalf("setup failed for suite %v: %s", sType, err.Error())
-	}
-
-	return m.suite.(S)
+	sType := newSuiteManager(s).Type()
+	return defaultRegistry.get(tb, sType).(S)
 }
 
 // Teardown runs the Teardown method on registered suites who ran their Setup methods.
 // If a suite was registered but never retrieved (by using the Get function), its
 // teardown method will not be run.
+// Panics raised by Teardown methods are returned as a *PanicError; the remaining suites are still torn down.
 func Teardown() error {
-	var errs []error
-	for _, key := range defaultRegistry.teardownOrder {
-		m, ok := defaultRegistry.Get(key)
-		if !ok {
-			// This should never happen in theory - just making life easier in case is a bug is introduced.
-			return fmt.Errorf("suite %s specified by teardownOrder missing from defaultRegistry", key)
-		}
+	return defaultRegistry.Teardown()
+}
 
-		if err := m.Teardown(); err != nil {
-			errs = append(errs, errors.Wrapf(err, "--- ERROR: Teardown failed for suite %v", m.Type()))
-		}
-	}
+type suiteManager struct {
+	suite  Suite
+	name   string
+	config *suiteConfig
+	shared *sharedSuite
+
+	mux         sync.Mutex
+	closed      bool
+	setupRan    bool
+	setupSeq    uint64
+	setupErr    error
+	lastHealthy time.Time
+	restarts    int
+	snapshot    any
+	dirty       bool
+
+	setupDuration    time.Duration
+	teardownDuration time.Duration
+	teardownErr      error
+
+	testsMux sync.Mutex
+	tests    map[string]bool
+	usedBy   []string
+}
 
-	return multierr.Combine(errs...)
+func newSuiteManager(s Suite) *suiteManager {
+	return &suiteManager{
+		suite: s,
+		tests: map[string]bool{},
+	}
 }
 
-// Run is a helper method which calls m.Run and the Teardown function,
-// returning the exit code from m.Run or 1 if an error occured during Teardown.
-func Run(m *testing.M) int {
-	code := m.Run()
-	if err := Teardown(); err != nil {
-		for _, err := range multierr.Errors(err) {
-			fmt.Fprintln(os.Stderr, err.Error())
-		}
+func (s *suiteManager) Type() string {
+	return reflect.TypeOf(s.suite).String()
+}
 
-		return 1
+// Name identifies the suite instance in reports.
+func (s *suiteManager) Name() string {
+	if s.name != "" {
+		return s.name
 	}
 
-	return code
+	return s.Type()
 }
 
-type suiteManager struct {
-	suite Suite
+// Use prepares the suite for use by the test tb: its Setup method, health check, and per-test hooks are ran as needed.
+// If the suite cannot be used, tb.Skip or tb.Fatal will be called.
+func (s *suiteManager) Use(tb testing.TB) {
+	tb.Helper()
+
+	if testing.Short() && isIntegration(s.suite) {
+		tb.Skipf("skipping integration suite %v in short mode", s.Type())
+	}
+
+	dependencies.Observe(tb.Name(), s)
 
-	mux      sync.Mutex
-	once     sync.Once
-	setupRan bool
-	setupErr error
+	_, warming := tb.(*warmupTB)
+	if !warming {
+		s.RecordUse(tb.Name())
+	}
+
+	// Exclude setup time from benchmark results.
+	if b, ok := tb.(benchmarkTimer); ok && s.needsSetup() {
+		b.StopTimer()
+		defer func() {
+			b.ResetTimer()
+			b.StartTimer()
+		}()
+	}
+
+	err := s.Setup(tb)
+	if err == nil {
+		err = s.CheckHealth(tb)
+	}
+
+	if err != nil {
+		if errors.Is(err, ErrUnavailable) {
+			tb.Skipf("suite %v is unavailable: %s", s.Type(), err.Error())
+		}
+
+		tb.Fatalf("setup failed for suite %v: %s", s.Type(), err.Error())
+	}
+
+	if !warming {
+		s.BeginTest(tb)
+	}
 }
 
-func newSuiteManager(s Suite) *suiteManager {
-	return &suiteManager{suite: s}
+// needsSetup returns true if the suite's Setup method has not been ran.
+func (s *suiteManager) needsSetup() bool {
+	ran, _, _ := s.setupStatus()
+	return !ran
 }
 
-func (s *suiteManager) Type() string {
-	return reflect.TypeOf(s.suite).String()
+// setupStatus returns whether the suite's Setup method has been ran, the sequence number
+// recording when it completed, and the error it returned.
+func (s *suiteManager) setupStatus() (ran bool, seq uint64, err error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.setupRan, s.setupSeq, s.setupErr
 }
 
 func (s *suiteManager) Setup(tb testing.TB) error {
 	s.mux.Lock()
 	defer s.mux.Unlock()
 
-	if s.setupErr != nil {
-		return s.setupErr
+	if s.closed {
+		return errTornDown
 	}
 
-	s.once.Do(func() {
-		s.setupErr = s.suite.Setup(tb)
-		s.setupRan = true
-	})
+	if !s.setupRan {
+		s.runSetup(tb)
+	}
 
 	return s.setupErr
 }
 
+// runSetup runs the suite's setup and records its outcome.
+// The caller must hold s.mux.
+func (s *suiteManager) runSetup(tb testing.TB) {
+	defer dependencies.BeginSetup(tb.Name(), s)()
+
+	start := time.Now()
+	s.setupErr = callSafely(s.Type(), "Setup", func() error { return s.setup(tb) })
+	s.setupDuration = time.Since(start)
+	s.setupRan = true
+	// Suites restarted by a health check keep their original position in the teardown order,
+	// so they are still torn down after the suites which depend on them.
+	if s.setupSeq == 0 {
+		s.setupSeq = atomic.AddUint64(&setupSeq, 1)
+	}
+	s.lastHealthy = time.Now()
+	s.dirty = false
+}
+
+// setup applies the suite's configuration and runs its Setup method.
+func (s *suiteManager) setup(tb testing.TB) error {
+	if s.config != nil {
+		if err := s.config.Apply(s.suite); err != nil {
+			return errors.Wrap(err, "invalid configuration")
+		}
+	}
+
+	var err error
+	if ss, ok := s.suite.(SharedSuite); ok && *sharedDir != "" {
+		s.shared = newSharedSuite(ss, *sharedDir)
+		err = s.shared.Setup(tb)
+	} else {
+		err = s.suite.Setup(tb)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if snap, ok := s.suite.(Snapshotter); ok {
+		if s.snapshot, err = snap.Snapshot(); err != nil {
+			return errors.Wrap(err, "failed to snapshot suite")
+		}
+	}
+
+	return nil
+}
+
+// RecordUse records that the test named name retrieved the suite.
+func (s *suiteManager) RecordUse(name string) {
+	s.testsMux.Lock()
+	defer s.testsMux.Unlock()
+
+	for _, n := range s.usedBy {
+		if n == name {
+			return
+		}
+	}
+
+	s.usedBy = append(s.usedBy, name)
+}
+
+// BeginTest restores the suite's snapshot, runs its BeforeTest hook, and registers its AfterTest hook for tb.
+// The hooks are only ran once per test, keyed by tb.Name().
+func (s *suiteManager) BeginTest(tb testing.TB) {
+	tb.Helper()
+
+	snap, hasSnap := s.suite.(Snapshotter)
+	before, hasBefore := s.suite.(BeforeTester)
+	after, hasAfter := s.suite.(AfterTester)
+	if !hasSnap && !hasBefore && !hasAfter {
+		return
+	}
+
+	name := tb.Name()
+	s.testsMux.Lock()
+	if s.tests[name] {
+		s.testsMux.Unlock()
+		return
+	}
+	s.tests[name] = true
+	s.testsMux.Unlock()
+
+	// Register the cleanup before running BeforeTest so AfterTest
+	// can cleanup state left behind by a failed BeforeTest.
+	tb.Cleanup(func() {
+		defer func() {
+			s.testsMux.Lock()
+			delete(s.tests, name)
+			s.testsMux.Unlock()
+		}()
+
+		if !hasAfter {
+			return
+		}
+
+		if err := after.AfterTest(tb); err != nil {
+			tb.Errorf("AfterTest failed for suite %v: %s", s.Type(), err.Error())
+		}
+	})
+
+	if hasSnap {
+		if err := s.restore(snap); err != nil {
+			tb.Fatalf("restore failed for suite %v: %s", s.Type(), err.Error())
+		}
+	}
+
+	if hasBefore {
+		if err := before.BeforeTest(tb); err != nil {
+			tb.Fatalf("BeforeTest failed for suite %v: %s", s.Type(), err.Error())
+		}
+	}
+}
+
+// restore restores the suite's snapshot if a previous test may have modified its state.
+func (s *suiteManager) restore(snap Snapshotter) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if !s.dirty {
+		s.dirty = true
+		return nil
+	}
+
+	return snap.Restore(s.snapshot)
+}
+
+// Teardown runs the suite's Teardown method if its Setup method has been ran.
+// Once Teardown has been called, the suite will not be set up (or torn down) again.
 func (s *suiteManager) Teardown() error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.closed {
+		return nil
+	}
+
+	s.closed = true
 	if !s.setupRan {
 		return nil
 	}
 
-	return s.suite.Teardown()
+	return s.runTeardown()
 }
 
-var defaultRegistry = &registry{
-	suites:        map[string]*suiteManager{},
-	teardownOrder: []string{},
+// runTeardown runs the suite's Teardown method (or detaches from a shared suite) and records its outcome.
+// The caller must hold s.mux.
+func (s *suiteManager) runTeardown() error {
+	start := time.Now()
+	s.teardownErr = callSafely(s.Type(), "Teardown", func() error {
+		if s.shared != nil {
+			return s.shared.Teardown()
+		}
+
+		return s.suite.Teardown()
+	})
+	s.teardownDuration = time.Since(start)
+	return s.teardownErr
+}
+
+// Report returns the lifecycle report for the suite.
+func (s *suiteManager) Report() SuiteReport {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.testsMux.Lock()
+	defer s.testsMux.Unlock()
+
+	return SuiteReport{
+		Suite:            s.Name(),
+		SetupDuration:    s.setupDuration,
+		SetupError:       errorString(s.setupErr),
+		TeardownDuration: s.teardownDuration,
+		TeardownError:    errorString(s.teardownErr),
+		Restarts:         s.restarts,
+		Tests:            append([]string{}, s.usedBy...),
+	}
+}
+
+var defaultRegistry = newRegistry()
+
+// errTornDown is returned when a suite is used after the registry's suites have been torn down.
+var errTornDown = errors.New("suites have been torn down")
+
+// setupSeq is incremented each time a suite's setup completes, recording the order in which setups completed.
+var setupSeq uint64
+
+// A TeardownOrder determines the order in which Teardown runs the suites' Teardown methods.
+type TeardownOrder int
+
+const (
+	// SetupOrder tears suites down in the reverse order their Setup methods completed.
+	// Since a suite which calls Get within its Setup method completes its setup after
+	// the suites it depends on, dependents are torn down before their dependencies.
+	// Suites restarted by a health check (see HealthChecker) keep the position of their first setup.
+	// This is the default.
+	SetupOrder TeardownOrder = iota
+
+	// RegistrationOrder tears suites down in the reverse order they were registered (first in, last out),
+	// regardless of when their Setup methods ran.
+	RegistrationOrder
+)
+
+// SetTeardownOrder sets the order in which Teardown runs the suites' Teardown methods.
+// It should be called in TestMain, before m.Run.
+func SetTeardownOrder(order TeardownOrder) {
+	defaultRegistry.order = order
 }
 
 type registry struct {
-	suites        map[string]*suiteManager
-	teardownOrder []string
+	suites    map[string]*suiteManager
+	pools     map[string]*pool
+	factories map[string]*factory
+	order     TeardownOrder
+	// closed is set to 1 once Teardown has been called.
+	closed int32
+	// registrationOrder holds the registered keys, most recently registered first.
+	registrationOrder []string
+}
+
+func newRegistry() *registry {
+	return &registry{
+		suites:            map[string]*suiteManager{},
+		pools:             map[string]*pool{},
+		factories:         map[string]*factory{},
+		order:             SetupOrder,
+		registrationOrder: []string{},
+	}
 }
 
 func (r *registry) Insert(key string, s *suiteManager) error {
-	if _, ok := r.suites[key]; ok {
+	if r.registered(key) {
 		return fmt.Errorf("suite %s has already been registered", key)
 	}
 
 	r.suites[key] = s
-	// Execute teardowns on a FILO basis.
-	r.teardownOrder = append([]string{key}, r.teardownOrder...)
+	r.registrationOrder = append([]string{key}, r.registrationOrder...)
 	return nil
 }
 
+func (r *registry) InsertPool(key string, p *pool) error {
+	if r.registered(key) {
+		return fmt.Errorf("suite %s has already been registered", key)
+	}
+
+	r.pools[key] = p
+	r.registrationOrder = append([]string{key}, r.registrationOrder...)
+	return nil
+}
+
+func (r *registry) InsertFactory(key string, f *factory) error {
+	if r.registered(key) {
+		return fmt.Errorf("suite %s has already been registered", key)
+	}
+
+	r.factories[key] = f
+	r.registrationOrder = append([]string{key}, r.registrationOrder...)
+	return nil
+}
+
+func (r *registry) registered(key string) bool {
+	_, isSuite := r.suites[key]
+	_, isPool := r.pools[key]
+	_, isFactory := r.factories[key]
+	return isSuite || isPool || isFactory
+}
+
 func (r *registry) Get(key string) (*suiteManager, bool) {
 	s, ok := r.suites[key]
 	return s, ok
 }
+
+func (r *registry) GetPool(key string) (*pool, bool) {
+	p, ok := r.pools[key]
+	return p, ok
+}
+
+func (r *registry) GetFactory(key string) (*factory, bool) {
+	f, ok := r.factories[key]
+	return f, ok
+}
+
+// Managers returns the manager of each suite instance in the registry, in the order they should be torn down.
+func (r *registry) Managers() []*suiteManager {
+	managers := r.registeredManagers()
+	if r.order == SetupOrder {
+		// Suites which never ran their Setup methods have a zero setupSeq, so they are sorted last.
+		seqs := map[*suiteManager]uint64{}
+		for _, m := range managers {
+			_, seqs[m], _ = m.setupStatus()
+		}
+
+		sort.SliceStable(managers, func(i, j int) bool {
+			return seqs[managers[i]] > seqs[managers[j]]
+		})
+	}
+
+	return managers
+}
+
+// registeredManagers returns the manager of each suite instance in the registry, most recently registered first.
+func (r *registry) registeredManagers() []*suiteManager {
+	managers := []*suiteManager{}
+	for _, key := range r.registrationOrder {
+		if m, ok := r.suites[key]; ok {
+			managers = append(managers, m)
+		}
+
+		if p, ok := r.pools[key]; ok {
+			managers = append(managers, p.Managers()...)
+		}
+
+		if f, ok := r.factories[key]; ok {
+			managers = append(managers, f.Managers()...)
+		}
+	}
+
+	return managers
+}
+
+// get returns the suite registered under key, running its Setup method and per-test hooks as needed.
+func (r *registry) get(tb testing.TB, key string) Suite {
+	tb.Helper()
+
+	m, ok := r.Get(key)
+	if !ok {
+		tb.Fatalf("suite of type %v has not been registered", key)
+	}
+
+	r.checkOpen(tb, key)
+	m.Use(tb)
+	return m.suite
+}
+
+// checkOpen calls tb.Fatal if the registry's suites have been torn down,
+// e.g. because the process was interrupted while tests were still running.
+func (r *registry) checkOpen(tb testing.TB, key string) {
+	tb.Helper()
+
+	if atomic.LoadInt32(&r.closed) == 1 {
+		tb.Fatalf("suite %v cannot be used: %s", key, errTornDown.Error())
+	}
+}
+
+// Teardown runs the Teardown method on the registry's suites in the order given by Managers.
+// Once Teardown has been called, the registry's suites can no longer be retrieved.
+func (r *registry) Teardown() error {
+	atomic.StoreInt32(&r.closed, 1)
+
+	var errs []error
+	for _, m := range r.Managers() {
+		if err := m.Teardown(); err != nil {
+			errs = append(errs, errors.Wrapf(err, "--- ERROR: Teardown failed for suite %v", m.Name()))
+		}
+	}
+
+	return multierr.Combine(errs...)
+}
@@ -75,9 +75,9 @@ func (p *ProductSuite) Teardown() error {
 }
 
 func TestMain(m *testing.M) {
-	// Teardowns happen in FILO order, so we
-	// register the db suite first since it should close after we
-	// cleanup our test user and product.
+	// Teardowns happen in the reverse order setups complete, so the
+	// db suite will close after we cleanup our test user and product,
+	// since their Setup methods retrieve the db suite.
 	suite.Register(&DBSuite{})
 	suite.Register(&UserSuite{})
 	suite.Register(&ProductSuite{ProductName: "Shampoo"})
@@ -0,0 +1,966 @@
+package suite
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/zpatrick/testx"
+	"github.com/zpatrick/testx/assert"
+	"go.uber.org/multierr"
+)
+
+func newTestRegistry(tb testing.TB, suites ...Suite) *registry {
+	r := newRegistry()
+	for _, s := range suites {
+		m := newSuiteManager(s)
+		assert.NilError(tb, r.Insert(m.Type(), m))
+	}
+
+	return r
+}
+
+type hookSuite struct {
+	Base
+
+	before []string
+	after  []string
+}
+
+func (h *hookSuite) BeforeTest(tb testing.TB) error {
+	h.before = append(h.before, tb.Name())
+	return nil
+}
+
+func (h *hookSuite) AfterTest(tb testing.TB) error {
+	h.after = append(h.after, tb.Name())
+	return nil
+}
+
+func TestGet_testHooks(t *testing.T) {
+	s := &hookSuite{}
+	r := newTestRegistry(t, s)
+	key := newSuiteManager(s).Type()
+
+	for _, name := range []string{"alpha", "bravo"} {
+		t.Run(name, func(t *testing.T) {
+			r.get(t, key)
+			r.get(t, key)
+
+			assert.Equal(t, len(s.before), len(s.after)+1)
+		})
+	}
+
+	expected := []string{"TestGet_testHooks/alpha", "TestGet_testHooks/bravo"}
+	assert.EqualSlices(t, s.before, expected)
+	assert.EqualSlices(t, s.after, expected)
+}
+
+func TestKeepMode(t *testing.T) {
+	testCases := []struct {
+		Mode            string
+		KeepPassed      bool
+		KeepFailed      bool
+		KeepInterrupted bool
+	}{
+		{"", false, false, false},
+		{"never", false, false, false},
+		{"failed", false, true, false},
+		{"always", true, true, true},
+		{"ALWAYS", true, true, true},
+	}
+
+	for _, tc := range testCases {
+		var k keepMode
+		assert.NilError(t, k.Set(tc.Mode))
+		assert.Equal(t, k.Keep(runPassed), tc.KeepPassed)
+		assert.Equal(t, k.Keep(runFailed), tc.KeepFailed)
+		assert.Equal(t, k.Keep(runInterrupted), tc.KeepInterrupted)
+	}
+
+	var k keepMode
+	assert.Error(t, k.Set("sometimes"))
+}
+
+type failingSuite struct {
+	Base
+}
+
+func (failingSuite) Teardown() error {
+	return errors.New("teardown failed")
+}
+
+func TestRegistry_Reports(t *testing.T) {
+	r := newTestRegistry(t, &hookSuite{}, &failingSuite{}, &Base{})
+
+	t.Run("alpha", func(t *testing.T) {
+		r.get(t, "*suite.hookSuite")
+		r.get(t, "*suite.failingSuite")
+	})
+	t.Run("bravo", func(t *testing.T) {
+		r.get(t, "*suite.hookSuite")
+	})
+	assert.Error(t, r.Teardown())
+
+	reports := r.Reports()
+	assert.Equal(t, len(reports), 2)
+	assert.Equal(t, reports[0].Suite, "*suite.hookSuite")
+	assert.EqualSlices(t, reports[0].Tests, []string{"TestRegistry_Reports/alpha", "TestRegistry_Reports/bravo"})
+	assert.Equal(t, reports[1].Suite, "*suite.failingSuite")
+	assert.Equal(t, reports[1].TeardownError, "teardown failed")
+}
+
+func TestWriteReports(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	original := *reportJSON
+	*reportJSON = path
+	defer func() { *reportJSON = original }()
+
+	reports := []SuiteReport{{
+		Suite:            "*suite.hookSuite",
+		SetupDuration:    1500 * time.Millisecond,
+		TeardownDuration: 2 * time.Millisecond,
+		TeardownError:    "teardown failed\nstack trace",
+		Restarts:         1,
+		Tests:            []string{"TestA", "TestB"},
+	}}
+
+	var table strings.Builder
+	assert.NilError(t, writeReports(&table, reports))
+	lines := strings.Split(strings.TrimSpace(table.String()), "\n")
+	assert.Equal(t, len(lines), 2)
+	assert.Equal(t, strings.Join(strings.Fields(lines[1]), " "), "*suite.hookSuite 1.5s 2ms 1 2 teardown: teardown failed")
+
+	data, err := os.ReadFile(path)
+	assert.NilError(t, err)
+
+	var decoded []SuiteReport
+	assert.NilError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, len(decoded), 1)
+	assert.DeepEqual(t, decoded[0], reports[0])
+
+	// The table can be disabled.
+	enabled := *reportTable
+	*reportTable = false
+	defer func() { *reportTable = enabled }()
+
+	table.Reset()
+	assert.NilError(t, writeReports(&table, reports))
+	assert.Equal(t, table.String(), "")
+}
+
+type unavailableSuite struct {
+	Base
+}
+
+func (unavailableSuite) Setup(tb testing.TB) error {
+	return Unavailablef("env var %s is not set", "APP_DB_HOST")
+}
+
+type integrationSuite struct {
+	Base
+	Integration
+
+	setupRan bool
+}
+
+func (i *integrationSuite) Setup(tb testing.TB) error {
+	i.setupRan = true
+	return nil
+}
+
+func TestGet_unavailable(t *testing.T) {
+	r := newTestRegistry(t, &unavailableSuite{})
+
+	var skipped bool
+	t.Run("alpha", func(t *testing.T) {
+		defer func() { skipped = t.Skipped() }()
+		r.get(t, "*suite.unavailableSuite")
+	})
+
+	assert.Equal(t, skipped, true)
+}
+
+func TestGet_integrationShort(t *testing.T) {
+	short := flag.Lookup("test.short").Value.String()
+	assert.NilError(t, flag.Set("test.short", "true"))
+	defer flag.Set("test.short", short)
+
+	s := &integrationSuite{}
+	r := newTestRegistry(t, s)
+
+	var skipped bool
+	t.Run("alpha", func(t *testing.T) {
+		defer func() { skipped = t.Skipped() }()
+		r.get(t, "*suite.integrationSuite")
+	})
+
+	assert.Equal(t, skipped, true)
+	assert.Equal(t, s.setupRan, false)
+}
+
+type configSuite struct {
+	Base
+
+	Host    string        `testx:"env=TESTX_TEST_HOST,default=localhost"`
+	Port    int           `testx:"env=TESTX_TEST_PORT,flag=testx.test.port,default=3306"`
+	Timeout time.Duration `testx:"default=5s"`
+	User    string        `testx:"env=TESTX_TEST_USER,required"`
+	Name    string        `testx:"default=users"`
+}
+
+func TestSuiteConfig_Apply(t *testing.T) {
+	t.Setenv("TESTX_TEST_HOST", "db.internal")
+	t.Setenv("TESTX_TEST_PORT", "3307")
+	t.Setenv("TESTX_TEST_USER", "root")
+
+	s := &configSuite{Name: "products"}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg, err := parseConfig(s, fs)
+	assert.NilError(t, err)
+	assert.NilError(t, fs.Parse([]string{"-testx.test.port=3308"}))
+
+	assert.NilError(t, cfg.Apply(s))
+	assert.Equal(t, s.Host, "db.internal")
+	assert.Equal(t, s.Port, 3308)
+	assert.Equal(t, s.Timeout, 5*time.Second)
+	assert.Equal(t, s.User, "root")
+	assert.Equal(t, s.Name, "products")
+}
+
+func TestSuiteConfig_ApplyErrors(t *testing.T) {
+	t.Setenv("TESTX_TEST_PORT", "not-a-port")
+
+	s := &configSuite{}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	cfg, err := parseConfig(s, fs)
+	assert.NilError(t, err)
+
+	err = cfg.Apply(s)
+	assert.Equal(t, len(multierr.Errors(err)), 2)
+}
+
+func TestParseConfig_invalidTag(t *testing.T) {
+	testCases := []struct {
+		Name  string
+		Suite Suite
+	}{
+		{"unknown option", &struct {
+			Base
+			Port int `testx:"envvar=PORT"`
+		}{}},
+		{"invalid default", &struct {
+			Base
+			Port int `testx:"default=abc"`
+		}{}},
+		{"unsupported type", &struct {
+			Base
+			Ports []int `testx:"env=PORTS"`
+		}{}},
+	}
+
+	for _, tc := range testCases {
+		_, err := parseConfig(tc.Suite, flag.NewFlagSet("test", flag.ContinueOnError))
+		assert.Error(t, err)
+	}
+}
+
+type sharedTestSuite struct {
+	Base
+
+	Addr string
+
+	setups, attaches, detaches, teardowns *int
+}
+
+func (s *sharedTestSuite) Setup(tb testing.TB) error {
+	*s.setups++
+	s.Addr = fmt.Sprintf("127.0.0.1:%d", 3305+*s.setups)
+	return nil
+}
+
+func (s *sharedTestSuite) State() ([]byte, error) {
+	return []byte(s.Addr), nil
+}
+
+func (s *sharedTestSuite) Attach(tb testing.TB, state []byte) error {
+	*s.attaches++
+	s.Addr = string(state)
+	return nil
+}
+
+func (s *sharedTestSuite) Detach() error {
+	*s.detaches++
+	return nil
+}
+
+func (s *sharedTestSuite) Teardown() error {
+	*s.teardowns++
+	return nil
+}
+
+func TestSharedSuite(t *testing.T) {
+	dir := *sharedDir
+	*sharedDir = t.TempDir()
+	defer func() { *sharedDir = dir }()
+
+	var setups, attaches, detaches, teardowns int
+	newSuite := func() *sharedTestSuite {
+		return &sharedTestSuite{setups: &setups, attaches: &attaches, detaches: &detaches, teardowns: &teardowns}
+	}
+
+	// Each manager simulates a separate test process.
+	first, second := newSuite(), newSuite()
+	firstM, secondM := newSuiteManager(first), newSuiteManager(second)
+
+	assert.NilError(t, firstM.Setup(t))
+	assert.NilError(t, secondM.Setup(t))
+	assert.Equal(t, setups, 1)
+	assert.Equal(t, attaches, 1)
+	assert.Equal(t, second.Addr, "127.0.0.1:3306")
+
+	assert.NilError(t, firstM.Teardown())
+	assert.Equal(t, detaches, 1)
+	assert.Equal(t, teardowns, 0)
+
+	assert.NilError(t, secondM.Teardown())
+	assert.Equal(t, detaches, 1)
+	assert.Equal(t, teardowns, 1)
+}
+
+func TestSharedSuite_staleState(t *testing.T) {
+	var setups, attaches, detaches, teardowns int
+	s := newSharedSuite(&sharedTestSuite{
+		setups: &setups, attaches: &attaches, detaches: &detaches, teardowns: &teardowns,
+	}, t.TempDir())
+
+	// No process can have the maximum pid, so the state appears to have been left by a process which crashed.
+	assert.NilError(t, s.writeState(&sharedState{Processes: []int{math.MaxInt32}, State: []byte("127.0.0.1:9999")}))
+
+	tb := &warmupTB{name: t.Name()}
+	assert.NilError(t, s.Setup(tb))
+	assert.Equal(t, setups, 1)
+	assert.Equal(t, attaches, 0)
+	assert.StringContains(t, tb.logs(), "discarding stale shared suite state")
+	assert.StringContains(t, tb.logs(), "127.0.0.1:9999")
+
+	st, err := s.readState()
+	assert.NilError(t, err)
+	assert.Equal(t, string(st.State), "127.0.0.1:3306")
+}
+
+// A sharedHealthSuite is a sharedTestSuite whose resources can be lost.
+type sharedHealthSuite struct {
+	sharedTestSuite
+	unhealthy bool
+}
+
+func (s *sharedHealthSuite) Healthy(ctx context.Context) error {
+	if s.unhealthy {
+		return errors.New("connection lost")
+	}
+
+	return nil
+}
+
+func TestSharedSuite_unhealthy(t *testing.T) {
+	dir := *sharedDir
+	*sharedDir = t.TempDir()
+	defer func() { *sharedDir = dir }()
+
+	interval := *healthInterval
+	*healthInterval = 0
+	defer func() { *healthInterval = interval }()
+
+	var setups, attaches, detaches, teardowns int
+	newSuite := func() *sharedHealthSuite {
+		return &sharedHealthSuite{sharedTestSuite: sharedTestSuite{
+			setups: &setups, attaches: &attaches, detaches: &detaches, teardowns: &teardowns,
+		}}
+	}
+
+	// Each manager simulates a separate test process.
+	first, second := newSuite(), newSuite()
+	firstM, secondM := newSuiteManager(first), newSuiteManager(second)
+	assert.NilError(t, firstM.Setup(t))
+	assert.NilError(t, secondM.Setup(t))
+
+	// The first process to detect the failure tears the suite down and sets it up again.
+	first.unhealthy, second.unhealthy = true, true
+	assert.NilError(t, firstM.CheckHealth(t))
+	assert.Equal(t, setups, 2)
+	assert.Equal(t, teardowns, 1)
+	assert.Equal(t, first.Addr, "127.0.0.1:3307")
+
+	// Other processes attach to the new state.
+	assert.NilError(t, secondM.CheckHealth(t))
+	assert.Equal(t, setups, 2)
+	assert.Equal(t, attaches, 2)
+	assert.Equal(t, detaches, 1)
+	assert.Equal(t, second.Addr, "127.0.0.1:3307")
+
+	assert.NilError(t, firstM.Teardown())
+	assert.NilError(t, secondM.Teardown())
+	assert.Equal(t, teardowns, 2)
+}
+
+type pooledSuite struct {
+	Base
+
+	inUse  bool
+	setups int
+	resets int
+}
+
+func (p *pooledSuite) Setup(tb testing.TB) error {
+	p.setups++
+	return nil
+}
+
+func (p *pooledSuite) Reset() error {
+	p.resets++
+	p.inUse = false
+	return nil
+}
+
+func TestRegistry_acquire(t *testing.T) {
+	var instances []*pooledSuite
+	factory := func() Suite {
+		s := &pooledSuite{}
+		instances = append(instances, s)
+		return s
+	}
+
+	p, err := newPool(factory, 2, flag.NewFlagSet("test", flag.ContinueOnError))
+	assert.NilError(t, err)
+
+	r := newRegistry()
+	assert.NilError(t, r.InsertPool(p.key, p))
+
+	t.Run("group", func(t *testing.T) {
+		for _, name := range []string{"alpha", "bravo", "charlie", "delta"} {
+			t.Run(name, func(t *testing.T) {
+				t.Parallel()
+
+				s := r.acquire(t, "*suite.pooledSuite").(*pooledSuite)
+				if s.inUse {
+					t.Fatal("acquired an instance which is already in use")
+				}
+				s.inUse = true
+			})
+		}
+	})
+
+	assert.Equal(t, len(instances), 2)
+	assert.Equal(t, instances[0].setups+instances[1].setups, 2)
+	assert.Equal(t, instances[0].resets+instances[1].resets, 4)
+	assert.Equal(t, len(r.Managers()), 2)
+}
+
+type healthSuite struct {
+	healthy   bool
+	setups    int
+	teardowns int
+}
+
+func (h *healthSuite) Setup(tb testing.TB) error {
+	h.setups++
+	h.healthy = true
+	return nil
+}
+
+func (h *healthSuite) Teardown() error {
+	h.teardowns++
+	return nil
+}
+
+func (h *healthSuite) Healthy(ctx context.Context) error {
+	if !h.healthy {
+		return errors.New("connection lost")
+	}
+
+	return nil
+}
+
+func TestGet_healthCheck(t *testing.T) {
+	interval := *healthInterval
+	*healthInterval = 0
+	defer func() { *healthInterval = interval }()
+
+	s := &healthSuite{}
+	r := newTestRegistry(t, s)
+
+	r.get(t, "*suite.healthSuite")
+	r.get(t, "*suite.healthSuite")
+	assert.Equal(t, s.setups, 1)
+
+	s.healthy = false
+	r.get(t, "*suite.healthSuite")
+	assert.Equal(t, s.setups, 2)
+	assert.Equal(t, s.teardowns, 1)
+	assert.Equal(t, r.Reports()[0].Restarts, 1)
+}
+
+type panicSuite struct {
+	Base
+}
+
+func (panicSuite) Setup(tb testing.TB) error {
+	panic("setup exploded")
+}
+
+func (panicSuite) Teardown() error {
+	panic("teardown exploded")
+}
+
+func TestSuiteManager_setupPanic(t *testing.T) {
+	m := newSuiteManager(&panicSuite{})
+
+	var panicErr *PanicError
+	assert.ErrorAs(t, m.Setup(t), &panicErr)
+	assert.Equal(t, panicErr.Method, "Setup")
+	assert.Equal(t, panicErr.Suite, "*suite.panicSuite")
+	assert.Equal(t, panicErr.Value.(string), "setup exploded")
+}
+
+type nilPanicSuite struct {
+	Base
+}
+
+func (nilPanicSuite) Setup(tb testing.TB) error {
+	panic(nil)
+}
+
+func TestSuiteManager_setupNilPanic(t *testing.T) {
+	m := newSuiteManager(&nilPanicSuite{})
+
+	var panicErr *PanicError
+	assert.ErrorAs(t, m.Setup(t), &panicErr)
+	assert.Equal(t, panicErr.Method, "Setup")
+}
+
+func TestRegistry_TeardownPanic(t *testing.T) {
+	s := &healthSuite{}
+	r := newTestRegistry(t, s, &panicSuite{})
+	for _, m := range r.Managers() {
+		m.Setup(t)
+	}
+
+	err := r.Teardown()
+	assert.Equal(t, len(multierr.Errors(err)), 1)
+
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, panicErr.Method, "Teardown")
+	assert.Equal(t, s.teardowns, 1)
+}
+
+func TestRegistry_getAfterTeardown(t *testing.T) {
+	s := &healthSuite{}
+	r := newTestRegistry(t, s)
+	r.get(t, "*suite.healthSuite")
+	assert.NilError(t, r.Teardown())
+
+	// Suites aren't set up again once they have been torn down, e.g. by an interrupt.
+	tb := &warmupTB{name: t.Name()}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.get(tb, "*suite.healthSuite")
+	}()
+	<-done
+
+	assert.Equal(t, tb.Failed(), true)
+	assert.StringContains(t, tb.logs(), "suites have been torn down")
+	assert.ErrorIs(t, r.warm("*suite.healthSuite"), errTornDown)
+	assert.NilError(t, r.Teardown())
+	assert.Equal(t, s.setups, 1)
+	assert.Equal(t, s.teardowns, 1)
+}
+
+func TestRegistry_concurrentTeardown(t *testing.T) {
+	s := &healthSuite{}
+	r := newTestRegistry(t, s)
+	m, _ := r.Get("*suite.healthSuite")
+
+	errc := make(chan error, 1)
+	go func() { errc <- r.Teardown() }()
+
+	// Depending on which goroutine wins, the suite is either set up and torn down, or never set up.
+	if err := m.Setup(t); err != nil {
+		assert.ErrorIs(t, err, errTornDown)
+	}
+
+	assert.NilError(t, <-errc)
+	assert.Equal(t, s.setups, s.teardowns)
+}
+
+type fatalSuite struct {
+	Base
+}
+
+func (fatalSuite) Setup(tb testing.TB) error {
+	tb.Fatal("dependency failed")
+	return nil
+}
+
+func TestRegistry_warm(t *testing.T) {
+	h := &healthSuite{}
+	r := newTestRegistry(t, h, &fatalSuite{}, &unavailableSuite{})
+
+	assert.NilError(t, r.warm("*suite.healthSuite"))
+	assert.Equal(t, h.setups, 1)
+
+	// Warming doesn't count as a test using the suite.
+	r.get(t, "*suite.healthSuite")
+	assert.Equal(t, h.setups, 1)
+	assert.EqualSlices(t, r.Reports()[0].Tests, []string{"TestRegistry_warm"})
+
+	err := r.warm("*suite.fatalSuite")
+	assert.Error(t, err)
+	assert.Equal(t, err.Error(), "dependency failed")
+
+	assert.ErrorIs(t, r.warm("*suite.unavailableSuite"), ErrUnavailable)
+	assert.Error(t, r.warm("*suite.unregisteredSuite"))
+}
+
+// A timerTB records calls to the benchmark timer methods.
+type timerTB struct {
+	testing.TB
+
+	running bool
+	calls   []string
+}
+
+func (tb *timerTB) StartTimer() {
+	tb.running = true
+	tb.calls = append(tb.calls, "StartTimer")
+}
+
+func (tb *timerTB) StopTimer() {
+	tb.running = false
+	tb.calls = append(tb.calls, "StopTimer")
+}
+
+func (tb *timerTB) ResetTimer() {
+	tb.calls = append(tb.calls, "ResetTimer")
+}
+
+// A timedSuite records whether the benchmark timer was running during its Setup method.
+type timedSuite struct {
+	Base
+
+	timer        *timerTB
+	timerRunning bool
+}
+
+func (s *timedSuite) Setup(tb testing.TB) error {
+	s.timerRunning = s.timer.running
+	return nil
+}
+
+func TestGet_benchmarkTimer(t *testing.T) {
+	timer := &timerTB{TB: t, running: true}
+	s := &timedSuite{timer: timer}
+	r := newTestRegistry(t, s)
+
+	// Wrappers which forward the timer methods are treated as benchmarks.
+	tb := testx.Wrap(timer, testx.WithPrefixLogging(testx.PrefixLoggingOptions{Prefix: "bench"}))
+	r.get(tb, "*suite.timedSuite")
+	assert.Equal(t, s.timerRunning, false)
+	assert.EqualSlices(t, timer.calls, []string{"StopTimer", "ResetTimer", "StartTimer"})
+
+	// The timer is only stopped while Setup runs.
+	r.get(tb, "*suite.timedSuite")
+	assert.Equal(t, len(timer.calls), 3)
+}
+
+func BenchmarkGet(b *testing.B) {
+	r := newTestRegistry(b, &hookSuite{})
+	for i := 0; i < b.N; i++ {
+		r.get(b, "*suite.hookSuite")
+	}
+}
+
+func TestRegistry_Graph(t *testing.T) {
+	r := newTestRegistry(t, &healthSuite{}, &hookSuite{}, &Base{})
+	db, _ := r.Get("*suite.healthSuite")
+	user, _ := r.Get("*suite.hookSuite")
+
+	// Simulate hookSuite's Setup method retrieving healthSuite.
+	end := dependencies.BeginSetup(t.Name(), user)
+	r.get(t, "*suite.healthSuite")
+	end()
+	r.get(t, "*suite.hookSuite")
+	assert.Equal(t, db.setupRan, true)
+
+	g := r.Graph()
+	assert.EqualSlices(t, g.Nodes, []string{"*suite.hookSuite", "*suite.healthSuite"})
+	assert.Equal(t, len(g.Edges), 1)
+	assert.Equal(t, g.Edges[0], Edge{From: "*suite.hookSuite", To: "*suite.healthSuite"})
+	assert.Equal(t, g.DOT(), "digraph suites {\n\t\"*suite.hookSuite\";\n\t\"*suite.healthSuite\";\n\t\"*suite.hookSuite\" -> \"*suite.healthSuite\";\n}\n")
+	assert.Equal(t, strings.Contains(g.String(), "(!)"), false)
+}
+
+type snapshotSuite struct {
+	Base
+
+	Rows     []string
+	restores int
+}
+
+func (s *snapshotSuite) Setup(tb testing.TB) error {
+	s.Rows = []string{"alice"}
+	return nil
+}
+
+func (s *snapshotSuite) Snapshot() (any, error) {
+	return append([]string{}, s.Rows...), nil
+}
+
+func (s *snapshotSuite) Restore(snapshot any) error {
+	s.restores++
+	s.Rows = append([]string{}, snapshot.([]string)...)
+	return nil
+}
+
+func TestGet_snapshot(t *testing.T) {
+	s := &snapshotSuite{}
+	r := newTestRegistry(t, s)
+
+	for _, name := range []string{"alpha", "bravo", "charlie"} {
+		t.Run(name, func(t *testing.T) {
+			r.get(t, "*suite.snapshotSuite")
+			r.get(t, "*suite.snapshotSuite")
+			assert.EqualSlices(t, s.Rows, []string{"alice"})
+
+			s.Rows = append(s.Rows, name)
+		})
+	}
+
+	assert.Equal(t, s.restores, 2)
+}
+
+type productSuite struct {
+	Base
+
+	Name   string
+	setups int
+}
+
+func (p *productSuite) Setup(tb testing.TB) error {
+	p.setups++
+	return nil
+}
+
+func TestRegistry_getWith(t *testing.T) {
+	f, err := newFactory(
+		reflect.TypeOf(&productSuite{}),
+		reflect.TypeOf(""),
+		func(params any) Suite { return &productSuite{Name: params.(string)} },
+		flag.NewFlagSet("test", flag.ContinueOnError),
+	)
+	assert.NilError(t, err)
+
+	r := newRegistry()
+	assert.NilError(t, r.InsertFactory(f.key, f))
+
+	shampoo := r.getWith(t, "*suite.productSuite", "Shampoo").(*productSuite)
+	soap := r.getWith(t, "*suite.productSuite", "Soap").(*productSuite)
+	assert.Equal(t, shampoo.Name, "Shampoo")
+	assert.Equal(t, soap.Name, "Soap")
+	assert.Equal(t, r.getWith(t, "*suite.productSuite", "Shampoo").(*productSuite), shampoo)
+	assert.Equal(t, shampoo.setups, 1)
+
+	managers := r.Managers()
+	assert.Equal(t, len(managers), 2)
+	assert.Equal(t, managers[0].Name(), "*suite.productSuite(Soap)")
+	assert.Equal(t, managers[1].Name(), "*suite.productSuite(Shampoo)")
+}
+
+type orderedSuite struct {
+	name      string
+	teardowns *[]string
+}
+
+func (o *orderedSuite) Setup(tb testing.TB) error { return nil }
+
+func (o *orderedSuite) Teardown() error {
+	*o.teardowns = append(*o.teardowns, o.name)
+	return nil
+}
+
+type (
+	orderedSuiteA struct{ orderedSuite }
+	orderedSuiteB struct{ orderedSuite }
+	orderedSuiteC struct{ orderedSuite }
+)
+
+func TestRegistry_TeardownOrder(t *testing.T) {
+	testCases := []struct {
+		Order    TeardownOrder
+		Expected []string
+	}{
+		{SetupOrder, []string{"a", "b"}},
+		{RegistrationOrder, []string{"b", "a"}},
+	}
+
+	for _, tc := range testCases {
+		var teardowns []string
+		r := newTestRegistry(t,
+			&orderedSuiteA{orderedSuite{"a", &teardowns}},
+			&orderedSuiteB{orderedSuite{"b", &teardowns}},
+			&orderedSuiteC{orderedSuite{"c", &teardowns}},
+		)
+		r.order = tc.Order
+
+		// Set up in the reverse order of registration.
+		r.get(t, "*suite.orderedSuiteB")
+		r.get(t, "*suite.orderedSuiteA")
+
+		assert.NilError(t, r.Teardown())
+		assert.EqualSlices(t, teardowns, tc.Expected)
+	}
+}
+
+type restartingSuite struct {
+	healthSuite
+	teardowns *[]string
+}
+
+func (r *restartingSuite) Teardown() error {
+	*r.teardowns = append(*r.teardowns, "dependency")
+	return r.healthSuite.Teardown()
+}
+
+type dependentSuite struct {
+	r         *registry
+	teardowns *[]string
+}
+
+func (d *dependentSuite) Setup(tb testing.TB) error {
+	d.r.get(tb, "*suite.restartingSuite")
+	return nil
+}
+
+func (d *dependentSuite) Teardown() error {
+	*d.teardowns = append(*d.teardowns, "dependent")
+	return nil
+}
+
+func TestRegistry_TeardownOrder_restartedDependency(t *testing.T) {
+	interval := *healthInterval
+	*healthInterval = 0
+	defer func() { *healthInterval = interval }()
+
+	var teardowns []string
+	dep := &restartingSuite{teardowns: &teardowns}
+	r := newTestRegistry(t, dep)
+	user := newSuiteManager(&dependentSuite{r: r, teardowns: &teardowns})
+	assert.NilError(t, r.Insert(user.Type(), user))
+
+	r.get(t, "*suite.dependentSuite")
+	dep.healthy = false
+	r.get(t, "*suite.restartingSuite")
+	assert.Equal(t, dep.setups, 2)
+
+	teardowns = nil
+	assert.NilError(t, r.Teardown())
+	assert.EqualSlices(t, teardowns, []string{"dependent", "dependency"})
+}
+
+func TestInterruptHandler_signal(t *testing.T) {
+	sigs := make(chan os.Signal, 1)
+	codes := make(chan int, 1)
+	var teardowns int32
+
+	var signalsStopped int32
+	h := &interruptHandler{
+		signals:         sigs,
+		stopSignals:     func() { atomic.StoreInt32(&signalsStopped, 1) },
+		teardownTimeout: time.Second,
+		teardown: func() error {
+			// Signals must be stopped before teardowns start, so they can be skipped with another signal.
+			atomic.AddInt32(&teardowns, atomic.LoadInt32(&signalsStopped))
+			return nil
+		},
+		exit: func(code int) { codes <- code },
+	}
+
+	stop := h.Start()
+	defer stop()
+
+	sigs <- syscall.SIGTERM
+	assert.Equal(t, <-codes, 128+int(syscall.SIGTERM))
+	assert.Equal(t, atomic.LoadInt32(&teardowns), int32(1))
+}
+
+func TestInterruptHandler_timeout(t *testing.T) {
+	blocked := make(chan struct{})
+	defer close(blocked)
+
+	timedOut := make(chan time.Duration, 1)
+	h := &interruptHandler{
+		testTimeout:     200 * time.Millisecond,
+		teardownTimeout: time.Hour,
+		teardown:        func() error { <-blocked; return nil },
+		timedOut:        func(time.Duration) { timedOut <- 0 },
+	}
+
+	// The grace period is limited to a tenth of the test timeout, and a teardown
+	// which does not complete must not delay the timeout beyond it.
+	assert.Equal(t, h.Grace(), 20*time.Millisecond)
+
+	start := time.Now()
+	stop := h.Start()
+	defer stop()
+
+	<-timedOut
+	assert.Between(t, time.Since(start), 200*time.Millisecond, time.Second)
+}
+
+func TestInterruptHandler_stop(t *testing.T) {
+	sigs := make(chan os.Signal, 1)
+	h := &interruptHandler{
+		signals:     sigs,
+		testTimeout: time.Hour,
+		teardown:    func() error { t.Error("teardown should not run"); return nil },
+	}
+
+	h.Start()()
+	sigs <- os.Interrupt
+	time.Sleep(10 * time.Millisecond)
+}
+
+func TestTeardownWithin(t *testing.T) {
+	assert.Equal(t, teardownWithin(time.Second, func() error { return nil }), true)
+
+	blocked := make(chan struct{})
+	defer close(blocked)
+	assert.Equal(t, teardownWithin(10*time.Millisecond, func() error { <-blocked; return nil }), false)
+}
+
+func TestTestTimeout(t *testing.T) {
+	f := flag.Lookup("test.timeout")
+	original := f.Value.String()
+	defer f.Value.Set(original)
+
+	assert.NilError(t, f.Value.Set("90s"))
+	assert.Equal(t, testTimeout(), 90*time.Second)
+}
+
+func TestSignalExitCode(t *testing.T) {
+	assert.Equal(t, signalExitCode(os.Interrupt), 130)
+	assert.Equal(t, signalExitCode(syscall.SIGTERM), 143)
+}
@@ -91,6 +91,32 @@ func (l *logger) Fatalf(format string, args ...any) {
 	l.formatf(l.TB.Fatalf, format, args...)
 }
 
+type benchmarkTimer interface {
+	StartTimer()
+	StopTimer()
+	ResetTimer()
+}
+
+// StartTimer, StopTimer and ResetTimer control the timer of the wrapped testing.TB if it is a benchmark,
+// so wrapped benchmarks can exclude setup time. They are no-ops otherwise.
+func (l *logger) StartTimer() {
+	if b, ok := l.TB.(benchmarkTimer); ok {
+		b.StartTimer()
+	}
+}
+
+func (l *logger) StopTimer() {
+	if b, ok := l.TB.(benchmarkTimer); ok {
+		b.StopTimer()
+	}
+}
+
+func (l *logger) ResetTimer() {
+	if b, ok := l.TB.(benchmarkTimer); ok {
+		b.ResetTimer()
+	}
+}
+
 type PrefixLoggingOptions struct {
 	DisableMethods LoggingMethodSwitch
 	Prefix         string